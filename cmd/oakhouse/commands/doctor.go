@@ -0,0 +1,750 @@
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/generators"
+	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/utils"
+	"github.com/spf13/cobra"
+)
+
+// Finding severities reported by doctor checks
+const (
+	doctorOK    = "ok"
+	doctorInfo  = "info"
+	doctorWarn  = "warn"
+	doctorError = "error"
+)
+
+// doctorFinding is a single diagnostic with an optional automatic fix
+type doctorFinding struct {
+	level      string
+	message    string
+	suggestion string
+	fix        func() error
+}
+
+// doctorCheck is a named group of diagnostics
+type doctorCheck struct {
+	name string
+	run  func() []doctorFinding
+}
+
+// integrationMarker is a file, or a snippet within a file, left behind by an integration
+type integrationMarker struct {
+	path     string
+	contains string
+}
+
+// doctorIntegration describes the artifacts of an integration and how to re-apply it.
+// Upgrades are artifacts added to the integration by later versions; projects integrated
+// before them are complete without them.
+type doctorIntegration struct {
+	name     string
+	markers  []integrationMarker
+	upgrades []integrationMarker
+	apply    func() error
+}
+
+// doctorIntegrations lists every integration whose partial application doctor detects.
+// Integrations are idempotent, so re-applying one completes the missing steps.
+var doctorIntegrations = []doctorIntegration{
+	{
+		name: "redis",
+		markers: []integrationMarker{
+			{path: "go.mod", contains: "github.com/redis/go-redis/v9"},
+			{path: ".env.example", contains: "REDIS_URL"},
+			{path: "config/redis_config.go"},
+			{path: "adapter/redis_adapter.go"},
+			{path: "util/redis_util.go"},
+			{path: "cmd/main.go", contains: "NewRedisAdapter"},
+			{path: "cmd/app_server.go", contains: "redisAdapter"},
+		},
+		upgrades: []integrationMarker{
+			{path: "util/redis_lock.go"},
+			{path: "util/eventbus.go"},
+			{path: "cmd/wire.go", contains: "ProvideRedisAdapter"},
+		},
+		apply: integrateRedis,
+	},
+	{
+		name: "tracing",
+		markers: []integrationMarker{
+			{path: "go.mod", contains: "go.opentelemetry.io/otel/sdk"},
+			{path: ".env.example", contains: "OTEL_TRACES_EXPORTER"},
+			{path: "config/tracing_config.go"},
+			{path: "adapter/tracing_adapter.go"},
+			{path: "util/tracing.go"},
+			{path: "cmd/main.go", contains: "InitializeTracing"},
+			{path: "cmd/app_server.go", contains: "otelfiber"},
+			{path: "adapter/postgres/gorm.go", contains: "tracing.NewPlugin"},
+		},
+		apply: integrateTracing,
+	},
+	{
+		name: "idempotency",
+		markers: []integrationMarker{
+			{path: ".env.example", contains: "IDEMPOTENCY_TTL"},
+			{path: "config/idempotency_config.go"},
+			{path: "util/idempotency.go"},
+			{path: "middleware/idempotency.go"},
+			{path: "cmd/app_server.go", contains: "middleware.Idempotency("},
+		},
+		apply: integrateIdempotency,
+	},
+	{
+		name: "cache",
+		markers: []integrationMarker{
+			{path: ".env.example", contains: "QUERY_CACHE_TTL"},
+			{path: "config/cache_config.go"},
+			{path: "util/query_cache.go"},
+			{path: "route/cache.go"},
+			{path: "cmd/app_server.go", contains: "route.UseQueryCache("},
+		},
+		apply: func() error {
+			return integrateCache(nil)
+		},
+	},
+	{
+		name: "ratelimit",
+		markers: []integrationMarker{
+			{path: ".env.example", contains: "RATE_LIMIT_WINDOW"},
+			{path: "config/ratelimit_config.go"},
+			{path: "util/ratelimit.go"},
+			{path: "middleware/ratelimit.go"},
+			{path: "cmd/app_server.go", contains: "middleware.RateLimit("},
+		},
+		apply: integrateRateLimit,
+	},
+	{
+		name: "session",
+		markers: []integrationMarker{
+			{path: ".env.example", contains: "SESSION_COOKIE_NAME"},
+			{path: "config/session_config.go"},
+			{path: "util/session.go"},
+			{path: "middleware/session.go"},
+			{path: "route/session.go"},
+			{path: "cmd/app_server.go", contains: "middleware.Session("},
+		},
+		apply: integrateSession,
+	},
+}
+
+// DoctorCmd creates the command for diagnosing common problems in an Oakhouse project.
+// Inspects the project layout, module imports, Go toolchain, route registration, integrations,
+// environment keys and generator version, printing a fix suggestion for every problem found.
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+func DoctorCmd() *cobra.Command {
+	var fix bool
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose common problems in the current project",
+		Long: `Inspect the current Oakhouse project and report problems with fix suggestions.
+
+Checks:
+• Project layout expected by the generators
+• go.mod module path vs. project imports
+• Go toolchain version vs. the go directive in go.mod
+• Setup*Routes functions in route/*.go that SetupRoutes never calls
+• Integrations that were only partially applied
+• Environment keys used by the code but missing from .env.example, and unused ones
+• CLI version vs. the version the project was generated with
+
+Use --fix to apply the automatic fixes that are available.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if !utils.FileExists("go.mod") {
+				fmt.Fprintln(os.Stderr, "❌ go.mod not found. Run 'oakhouse doctor' from your project root")
+				os.Exit(1)
+			}
+
+			if problems := runDoctor(fix); problems > 0 {
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&fix, "fix", false, "Apply available automatic fixes")
+
+	return cmd
+}
+
+// runDoctor runs every check, applies fixes when requested and returns the number of unresolved problems
+func runDoctor(fix bool) int {
+	checks := []doctorCheck{
+		{name: "Project layout", run: checkProjectLayout},
+		{name: "Module imports", run: checkModuleImports},
+		{name: "Go toolchain", run: checkGoToolchain},
+		{name: "Route registration", run: checkRouteRegistration},
+		{name: "Integrations", run: checkIntegrations},
+		{name: "Environment keys", run: checkEnvKeys},
+		{name: "Generator version", run: checkGeneratorVersion},
+	}
+
+	fmt.Println("🩺 Checking project health...")
+
+	problems, fixable, fixed := 0, 0, 0
+	for _, check := range checks {
+		fmt.Printf("\n%s\n", check.name)
+
+		for _, finding := range check.run() {
+			if fix && finding.fix != nil {
+				if err := finding.fix(); err != nil {
+					fmt.Printf("   ❌ %s\n      Fix failed: %v\n", finding.message, err)
+					problems++
+					continue
+				}
+				fmt.Printf("   🔧 %s (fixed)\n", finding.message)
+				fixed++
+				continue
+			}
+
+			switch finding.level {
+			case doctorOK:
+				fmt.Printf("   ✅ %s\n", finding.message)
+				continue
+			case doctorInfo:
+				fmt.Printf("   ℹ️  %s\n", finding.message)
+			case doctorWarn:
+				fmt.Printf("   ⚠️  %s\n", finding.message)
+				problems++
+			default:
+				fmt.Printf("   ❌ %s\n", finding.message)
+				problems++
+			}
+			if finding.suggestion != "" {
+				fmt.Printf("      💡 %s\n", finding.suggestion)
+			}
+			if finding.fix != nil {
+				fixable++
+			}
+		}
+	}
+
+	fmt.Println()
+	switch {
+	case problems == 0 && fixed == 0:
+		fmt.Println("✅ No problems found")
+	case problems == 0:
+		fmt.Printf("✅ Fixed %d problem(s). Run 'go mod tidy' and 'go build ./...' to confirm\n", fixed)
+	default:
+		fmt.Printf("⚠️  %d problem(s) found", problems)
+		if fixed > 0 {
+			fmt.Printf(", %d fixed", fixed)
+		}
+		fmt.Println()
+		if fixable > 0 {
+			fmt.Printf("💡 Run 'oakhouse doctor --fix' to fix %d of them automatically\n", fixable)
+		}
+	}
+
+	return problems
+}
+
+// checkProjectLayout verifies the files and directories the generators rely on
+func checkProjectLayout() []doctorFinding {
+	var findings []doctorFinding
+
+	requiredFiles := []string{"cmd/main.go", "cmd/app_server.go", "config/env_config.go", "route/v1.go"}
+	for _, file := range requiredFiles {
+		if !utils.FileExists(file) {
+			findings = append(findings, doctorFinding{
+				level:      doctorError,
+				message:    fmt.Sprintf("%s is missing", file),
+				suggestion: fmt.Sprintf("Restore %s from version control or copy it from a project created with 'oakhouse new'", file),
+			})
+		}
+	}
+
+	requiredDirs := []string{"adapter", "handler", "service", "repository", "dto", "scope", "model", "util", "middleware"}
+	for _, dir := range requiredDirs {
+		if utils.FileExists(dir) {
+			continue
+		}
+		dir := dir
+		findings = append(findings, doctorFinding{
+			level:      doctorWarn,
+			message:    fmt.Sprintf("%s/ directory is missing", dir),
+			suggestion: fmt.Sprintf("Create the %s/ directory", dir),
+			fix: func() error {
+				return os.MkdirAll(dir, 0755)
+			},
+		})
+	}
+
+	if utils.FileExists("config") && !utils.FileExists(filepath.Join("config", "loader.go")) {
+		findings = append(findings, doctorFinding{
+			level:      doctorWarn,
+			message:    "config/loader.go is missing, so integrations cannot register settings sections",
+			suggestion: "Run 'oakhouse upgrade' to migrate config/env_config.go to the struct tag loader",
+		})
+	}
+
+	if len(findings) == 0 {
+		findings = append(findings, doctorFinding{level: doctorOK, message: "Project layout is complete"})
+	}
+	return findings
+}
+
+// checkModuleImports reports project imports that do not use the module path declared in go.mod
+func checkModuleImports() []doctorFinding {
+	moduleName, err := utils.GetModuleName()
+	if err != nil {
+		return []doctorFinding{{
+			level:      doctorError,
+			message:    "go.mod does not declare a module path",
+			suggestion: "Add a 'module <name>' line to go.mod",
+		}}
+	}
+
+	requires := goModRequires()
+	projectDirs := map[string]bool{}
+	entries, _ := os.ReadDir(".")
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			projectDirs[entry.Name()] = true
+		}
+	}
+
+	// Maps each wrong module prefix to the files importing it
+	wrongPrefixes := map[string][]string{}
+	for _, file := range projectGoFiles() {
+		parsed, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.ImportsOnly)
+		if err != nil {
+			continue
+		}
+
+		for _, imp := range parsed.Imports {
+			path, _ := strconv.Unquote(imp.Path.Value)
+			if strings.HasPrefix(path, moduleName+"/") || isRequiredModule(path, requires) {
+				continue
+			}
+
+			segments := strings.Split(path, "/")
+			for i := 1; i < len(segments); i++ {
+				if projectDirs[segments[i]] {
+					prefix := strings.Join(segments[:i], "/")
+					wrongPrefixes[prefix] = appendUnique(wrongPrefixes[prefix], file)
+					break
+				}
+			}
+		}
+	}
+
+	if len(wrongPrefixes) == 0 {
+		return []doctorFinding{{level: doctorOK, message: fmt.Sprintf("All project imports use module %s", moduleName)}}
+	}
+
+	var findings []doctorFinding
+	for prefix, files := range wrongPrefixes {
+		prefix, files := prefix, files
+		findings = append(findings, doctorFinding{
+			level:      doctorError,
+			message:    fmt.Sprintf("%d file(s) import %q but go.mod declares module %q", len(files), prefix, moduleName),
+			suggestion: fmt.Sprintf("Replace the %q import prefix with %q (e.g. in %s)", prefix, moduleName, files[0]),
+			fix: func() error {
+				return rewriteImportPrefix(files, prefix, moduleName)
+			},
+		})
+	}
+	return findings
+}
+
+// checkGoToolchain compares the installed Go version with the go directive in go.mod
+func checkGoToolchain() []doctorFinding {
+	output, err := exec.Command("go", "env", "GOVERSION").Output()
+	if err != nil {
+		return []doctorFinding{{
+			level:      doctorError,
+			message:    "Go toolchain not found in PATH",
+			suggestion: "Install Go from https://go.dev/dl/ and make sure 'go' is in your PATH",
+		}}
+	}
+	installed := strings.TrimSpace(string(output))
+
+	required := goModDirective("go")
+	if required == "" {
+		return []doctorFinding{{
+			level:      doctorWarn,
+			message:    "go.mod has no go directive",
+			suggestion: "Add a 'go 1.21' line to go.mod",
+		}}
+	}
+
+	if utils.CompareVersions(installed, required) < 0 {
+		return []doctorFinding{{
+			level:      doctorError,
+			message:    fmt.Sprintf("Installed %s is older than go %s required by go.mod", installed, required),
+			suggestion: fmt.Sprintf("Upgrade Go to %s or newer", required),
+		}}
+	}
+
+	return []doctorFinding{{level: doctorOK, message: fmt.Sprintf("%s satisfies go %s", installed, required)}}
+}
+
+// checkRouteRegistration reports Setup*Routes functions that SetupRoutes never calls
+func checkRouteRegistration() []doctorFinding {
+	v1Path := filepath.Join("route", "v1.go")
+	v1Content, err := os.ReadFile(v1Path)
+	if err != nil {
+		return []doctorFinding{{level: doctorError, message: "route/v1.go not found, routes cannot be checked"}}
+	}
+
+	routeFiles, _ := filepath.Glob(filepath.Join("route", "*.go"))
+	setupFunc := regexp.MustCompile(`(?m)^func Setup(\w+)Routes\(`)
+
+	var findings []doctorFinding
+	total := 0
+	for _, file := range routeFiles {
+		if file == v1Path {
+			continue
+		}
+		content, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+
+		for _, match := range setupFunc.FindAllStringSubmatch(string(content), -1) {
+			resourceName := match[1]
+			total++
+			if strings.Contains(string(v1Content), fmt.Sprintf("Setup%sRoutes(", resourceName)) {
+				continue
+			}
+			findings = append(findings, doctorFinding{
+				level:      doctorError,
+				message:    fmt.Sprintf("Setup%sRoutes in %s is not registered in SetupRoutes", resourceName, file),
+				suggestion: fmt.Sprintf("Add 'Setup%sRoutes(api, db)' to SetupRoutes in route/v1.go", resourceName),
+				fix: func() error {
+					return generators.RegisterRoutes(resourceName)
+				},
+			})
+		}
+	}
+
+	if len(findings) == 0 {
+		findings = append(findings, doctorFinding{level: doctorOK, message: fmt.Sprintf("All %d resource route group(s) are registered", total)})
+	}
+	return findings
+}
+
+// checkIntegrations reports integrations whose steps were only partially applied
+func checkIntegrations() []doctorFinding {
+	var findings []doctorFinding
+
+	for _, integration := range doctorIntegrations {
+		present, missing := checkMarkers(integration.markers)
+
+		switch {
+		case len(present) == 0:
+			continue
+		case len(missing) == 0:
+			findings = append(findings, doctorFinding{level: doctorOK, message: fmt.Sprintf("%s integration is complete", integration.name)})
+			if _, upgrades := checkMarkers(integration.upgrades); len(upgrades) > 0 {
+				findings = append(findings, doctorFinding{
+					level:      doctorInfo,
+					message:    fmt.Sprintf("%s integration has optional upgrades: %s", integration.name, strings.Join(upgrades, ", ")),
+					suggestion: fmt.Sprintf("Run 'oakhouse integrate %s' again to add them", integration.name),
+				})
+			}
+		default:
+			findings = append(findings, doctorFinding{
+				level:      doctorError,
+				message:    fmt.Sprintf("%s integration is half-applied, missing: %s", integration.name, strings.Join(missing, ", ")),
+				suggestion: fmt.Sprintf("Run 'oakhouse integrate %s' again to complete the missing steps", integration.name),
+				fix:        integration.apply,
+			})
+		}
+	}
+
+	if len(findings) == 0 {
+		findings = append(findings, doctorFinding{level: doctorOK, message: "No integrations applied"})
+	}
+	return findings
+}
+
+// checkMarkers splits markers into the labels of those found in the project and those missing
+func checkMarkers(markers []integrationMarker) (present, missing []string) {
+	for _, marker := range markers {
+		label := marker.path
+		if marker.contains != "" {
+			label = fmt.Sprintf("%s (%s)", marker.path, marker.contains)
+		}
+
+		content, err := os.ReadFile(marker.path)
+		if err == nil && strings.Contains(string(content), marker.contains) {
+			present = append(present, label)
+		} else {
+			missing = append(missing, label)
+		}
+	}
+	return present, missing
+}
+
+// checkEnvKeys compares the environment keys read by the code with those documented in .env.example
+func checkEnvKeys() []doctorFinding {
+	documented, err := readEnvKeys(".env.example")
+	if err != nil {
+		return []doctorFinding{{
+			level:      doctorWarn,
+			message:    ".env.example not found",
+			suggestion: "Create .env.example documenting every setting the application reads",
+		}}
+	}
+
+	used := usedEnvKeys()
+
+	var missing, unused []string
+	for key := range used {
+		if _, ok := documented[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	for key := range documented {
+		if _, ok := used[key]; !ok {
+			unused = append(unused, key)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(unused)
+
+	var findings []doctorFinding
+	if len(missing) > 0 {
+		findings = append(findings, doctorFinding{
+			level:      doctorWarn,
+			message:    fmt.Sprintf("Keys read by the code but missing from .env.example: %s", strings.Join(missing, ", ")),
+			suggestion: "Document them in .env.example so every environment sets them",
+			fix: func() error {
+				var block strings.Builder
+				block.WriteString("\n# Added by oakhouse doctor\n")
+				for _, key := range missing {
+					block.WriteString(fmt.Sprintf("%s=%s\n", key, used[key]))
+				}
+				return appendEnvConfig(missing[0], block.String())
+			},
+		})
+	}
+	if len(unused) > 0 {
+		findings = append(findings, doctorFinding{
+			level:      doctorInfo,
+			message:    fmt.Sprintf("Keys in .env.example not read by the code: %s", strings.Join(unused, ", ")),
+			suggestion: "Remove them from .env.example if nothing else (e.g. docker-compose) relies on them",
+		})
+	}
+
+	if len(findings) == 0 {
+		findings = append(findings, doctorFinding{level: doctorOK, message: fmt.Sprintf("All %d environment keys are documented", len(used))})
+	}
+	return findings
+}
+
+// checkGeneratorVersion compares the CLI version with the version the project was generated with
+func checkGeneratorVersion() []doctorFinding {
+	projectVersion := projectGeneratorVersion()
+	if projectVersion == "" {
+		return []doctorFinding{{
+			level:   doctorInfo,
+			message: fmt.Sprintf("Could not determine the generator version of this project (CLI is v%s)", utils.Version),
+		}}
+	}
+
+	switch utils.CompareVersions(projectVersion, utils.Version) {
+	case -1:
+		return []doctorFinding{{
+			level:      doctorWarn,
+			message:    fmt.Sprintf("Project was generated with v%s, CLI is v%s", projectVersion, utils.Version),
+			suggestion: "Run 'oakhouse upgrade --dry-run' to preview the migration, then 'oakhouse upgrade'",
+		}}
+	case 1:
+		return []doctorFinding{{
+			level:      doctorWarn,
+			message:    fmt.Sprintf("Project was generated with v%s, newer than the CLI (v%s)", projectVersion, utils.Version),
+			suggestion: "Update the CLI: go install github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse@latest",
+		}}
+	}
+
+	return []doctorFinding{{level: doctorOK, message: fmt.Sprintf("Project and CLI are both v%s", utils.Version)}}
+}
+
+// projectGeneratorVersion reads the version recorded in .oakhouse.json, falling back to
+// the version badge in static/index.html for projects created before the manifest existed
+func projectGeneratorVersion() string {
+	if manifest, err := utils.ReadProjectManifest(); err == nil && manifest.Version != "" {
+		return manifest.Version
+	}
+
+	content, err := os.ReadFile(filepath.Join("static", "index.html"))
+	if err != nil {
+		return ""
+	}
+
+	match := regexp.MustCompile(`class="version-badge">\s*v?([0-9][0-9A-Za-z.\-]*)\s*<`).FindSubmatch(content)
+	if match == nil {
+		return ""
+	}
+	return string(match[1])
+}
+
+// projectGoFiles lists the Go files of the project, skipping vendored and hidden directories
+func projectGoFiles() []string {
+	var files []string
+	filepath.Walk(".", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			name := info.Name()
+			if path != "." && (name == "vendor" || name == "node_modules" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(path, ".go") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files
+}
+
+// goModRequires returns the module paths required in go.mod
+func goModRequires() []string {
+	content, err := os.ReadFile("go.mod")
+	if err != nil {
+		return nil
+	}
+
+	var modules []string
+	inBlock := false
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "require (":
+			inBlock = true
+		case inBlock && line == ")":
+			inBlock = false
+		case inBlock && line != "" && !strings.HasPrefix(line, "//"):
+			modules = append(modules, strings.Fields(line)[0])
+		case strings.HasPrefix(line, "require "):
+			if fields := strings.Fields(line); len(fields) >= 2 {
+				modules = append(modules, fields[1])
+			}
+		}
+	}
+	return modules
+}
+
+// goModDirective returns the value of a single-line go.mod directive such as "go"
+func goModDirective(name string) string {
+	content, err := os.ReadFile("go.mod")
+	if err != nil {
+		return ""
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == name {
+			return fields[1]
+		}
+	}
+	return ""
+}
+
+// isRequiredModule reports whether an import path belongs to one of the required modules
+func isRequiredModule(importPath string, modules []string) bool {
+	for _, module := range modules {
+		if importPath == module || strings.HasPrefix(importPath, module+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// rewriteImportPrefix replaces an import path prefix with the module path in the given files
+func rewriteImportPrefix(files []string, oldPrefix, moduleName string) error {
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		updated := strings.ReplaceAll(string(content), `"`+oldPrefix+`/`, `"`+moduleName+`/`)
+		if err := writeGoFile(file, updated); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readEnvKeys parses KEY=value lines of an env file, ignoring comments
+func readEnvKeys(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	keys := map[string]string{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		if key, value, ok := strings.Cut(line, "="); ok {
+			keys[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return keys, scanner.Err()
+}
+
+// usedEnvKeys collects the keys bound with env struct tags or read with os.Getenv/os.LookupEnv,
+// mapped to their default value when one is declared
+func usedEnvKeys() map[string]string {
+	tagPattern := regexp.MustCompile("`([^`]*\\benv:\"([A-Z0-9_]+)\"[^`]*)`")
+	defaultPattern := regexp.MustCompile(`\bdefault:"([^"]*)"`)
+	getenvPattern := regexp.MustCompile(`os\.(?:Getenv|LookupEnv)\("([A-Z0-9_]+)"\)`)
+
+	keys := map[string]string{}
+	for _, file := range projectGoFiles() {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+
+		for _, match := range tagPattern.FindAllStringSubmatch(string(content), -1) {
+			value := ""
+			if def := defaultPattern.FindStringSubmatch(match[1]); def != nil {
+				value = def[1]
+			}
+			keys[match[2]] = value
+		}
+		for _, match := range getenvPattern.FindAllStringSubmatch(string(content), -1) {
+			if _, ok := keys[match[1]]; !ok {
+				keys[match[1]] = ""
+			}
+		}
+	}
+	return keys
+}
+
+// appendUnique appends value to values unless it is already present
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -26,6 +26,7 @@ func GenerateCmd() *cobra.Command {
 
 	// Add all generate subcommands
 	cmd.AddCommand(generateResourceCmd())
+	cmd.AddCommand(generateFromDBCmd())
 	cmd.AddCommand(generateModelCmd())
 	cmd.AddCommand(generateHandlerCmd())
 	cmd.AddCommand(generateServiceCmd())
@@ -233,6 +234,10 @@ This command creates:
 Examples:
   oakhouse generate resource User name:string email:string age:int
   oakhouse generate resource Product title:string price:float description:text
+  oakhouse generate resource Article title:string body:text --searchable title,body
+  oakhouse generate resource AuditLog action:string --hard-delete
+  oakhouse generate resource Invoice number:string total:float64 --versioned
+  oakhouse generate resource Sensor serial:string --cache
   oakhouse generate resource --interactive
   oakhouse generate resource --dry-run User name:string`,
 		Args: cobra.MinimumNArgs(1),
@@ -243,6 +248,11 @@ Examples:
 			verbose, _ := cmd.Flags().GetBool("verbose")
 			skipValidation, _ := cmd.Flags().GetBool("skip-validation")
 			force, _ := cmd.Flags().GetBool("force")
+			searchable, _ := cmd.Flags().GetStringSlice("searchable")
+			searchLanguage, _ := cmd.Flags().GetString("search-language")
+			hardDelete, _ := cmd.Flags().GetBool("hard-delete")
+			versioned, _ := cmd.Flags().GetBool("versioned")
+			cached, _ := cmd.Flags().GetBool("cache")
 
 			resourceName := args[0]
 			fields := args[1:]
@@ -295,13 +305,36 @@ Examples:
 			}
 
 			// Generate resource
-			createdFiles, err := generators.GenerateResource(resourceName, fields)
+			opts := generators.NewResourceOptions(resourceName, fields)
+			opts.SoftDelete = !hardDelete
+			if versioned {
+				if err := opts.EnableVersioning(); err != nil {
+					fmt.Fprintf(os.Stderr, "❌ Invalid --versioned: %v\n", err)
+					os.Exit(1)
+				}
+			}
+			if cached {
+				if err := setupQueryCache(); err != nil {
+					fmt.Fprintf(os.Stderr, "❌ Invalid --cache: %v\n", err)
+					os.Exit(1)
+				}
+				opts.Cached = true
+			}
+			if len(searchable) > 0 {
+				if err := opts.EnableSearch(searchable, searchLanguage); err != nil {
+					fmt.Fprintf(os.Stderr, "❌ Invalid --searchable: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			createdFiles, err := generators.GenerateResourceWithOptions(resourceName, opts)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "❌ Error generating resource '%s': %v\n", resourceName, err)
 				fmt.Fprintf(os.Stderr, "\n💡 Troubleshooting tips:\n")
 				fmt.Fprintf(os.Stderr, "   - Ensure you're in a valid Go project directory\n")
 				fmt.Fprintf(os.Stderr, "   - Check that field syntax is correct (name:type)\n")
 				fmt.Fprintf(os.Stderr, "   - Verify write permissions in the target directory\n")
+				fmt.Fprintf(os.Stderr, "   - Run 'oakhouse doctor' to diagnose project problems\n")
 				os.Exit(1)
 			}
 
@@ -328,6 +361,11 @@ Examples:
 	cmd.Flags().BoolP("verbose", "v", false, "Enable verbose output with progress information")
 	cmd.Flags().Bool("skip-validation", false, "Skip input validation (use with caution)")
 	cmd.Flags().BoolP("force", "f", false, "Overwrite existing files without confirmation")
+	cmd.Flags().StringSlice("searchable", nil, "String fields to full-text search with ?q=, most important first")
+	cmd.Flags().String("search-language", "english", "PostgreSQL text search configuration for --searchable (e.g. english, simple)")
+	cmd.Flags().Bool("hard-delete", false, "Delete rows permanently instead of soft deleting them (no deleted_at column or trash endpoints)")
+	cmd.Flags().Bool("versioned", false, "Add a version column for optimistic locking with ETag, If-Match and If-None-Match")
+	cmd.Flags().Bool("cache", false, "Cache repository reads in Redis with tag invalidation on writes (requires the Redis integration)")
 
 	return cmd
 }
@@ -3,10 +3,13 @@ package commands
 
 import (
 	"fmt"
+	"go/format"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
+	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/generators"
 	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/templates"
 	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/utils"
 	"github.com/spf13/cobra"
@@ -25,6 +28,11 @@ func IntegrateCmd() *cobra.Command {
 
 	// Add subcommands
 	cmd.AddCommand(integrateRedisCmd())
+	cmd.AddCommand(integrateTracingCmd())
+	cmd.AddCommand(integrateIdempotencyCmd())
+	cmd.AddCommand(integrateCacheCmd())
+	cmd.AddCommand(integrateRateLimitCmd())
+	cmd.AddCommand(integrateSessionCmd())
 
 	return cmd
 }
@@ -34,7 +42,7 @@ func integrateRedisCmd() *cobra.Command {
 	return &cobra.Command{
 		Use:   "redis",
 		Short: "Integrate Redis caching support",
-		Long:  `Add Redis caching support to your Oakhouse project including configuration, connection setup, and caching utilities.`,
+		Long:  `Add Redis caching support to your Oakhouse project including configuration, connection setup, caching utilities, distributed locks and a Redis Streams event bus.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			if err := integrateRedis(); err != nil {
 				fmt.Printf("❌ Error integrating Redis: %v\n", err)
@@ -74,6 +82,13 @@ func integrateRedis() error {
 		return fmt.Errorf("failed to create Redis adapter: %v", err)
 	}
 
+	// Instrument the new client when tracing is already integrated
+	if utils.FileExists(filepath.Join("util", "tracing.go")) {
+		if err := instrumentRedisTracing(); err != nil {
+			return fmt.Errorf("failed to instrument Redis for tracing: %v", err)
+		}
+	}
+
 	// 5. Create Redis utilities
 	if err := createRedisUtils(); err != nil {
 		return fmt.Errorf("failed to create Redis utilities: %v", err)
@@ -89,10 +104,47 @@ func integrateRedis() error {
 		return fmt.Errorf("failed to update app_server.go for Redis: %v", err)
 	}
 
+	// 8. Provide the Redis adapter to NewAppServer in the wire set
+	if err := updateWireForRedis(); err != nil {
+		return fmt.Errorf("failed to update wire.go for Redis: %v", err)
+	}
+
+	// 9. Run the event bus with the server
+	if err := updateAppServerForEvents(); err != nil {
+		return fmt.Errorf("failed to update app_server.go for the event bus: %v", err)
+	}
+
+	// Services generated before the event bus publish nothing until they are changed by hand
+	if err := warnServicesWithoutEvents(); err != nil {
+		return err
+	}
+
+	// Keep Idempotency-Keys in Redis when idempotency is already integrated
+	if utils.FileExists(filepath.Join("util", "idempotency.go")) {
+		if err := useRedisForIdempotency(); err != nil {
+			return fmt.Errorf("failed to switch idempotency keys to Redis: %v", err)
+		}
+	}
+
+	// Share rate limits through Redis when rate limiting is already integrated
+	if utils.FileExists(filepath.Join("util", "ratelimit.go")) {
+		if err := useRedisForRateLimit(); err != nil {
+			return fmt.Errorf("failed to switch rate limits to Redis: %v", err)
+		}
+	}
+
+	// Keep sessions in Redis when sessions are already integrated
+	if utils.FileExists(filepath.Join("util", "session.go")) {
+		if err := useRedisForSessions(); err != nil {
+			return fmt.Errorf("failed to switch sessions to Redis: %v", err)
+		}
+	}
+
 	fmt.Println("\n📋 Next steps:")
 	fmt.Println("1. Run 'go mod tidy' to download Redis dependencies")
 	fmt.Println("2. Update your .env file with Redis configuration")
 	fmt.Println("3. Import and use Redis in your handlers and services")
+	fmt.Println("4. Subscribe to service events in cmd/events.go")
 
 	return nil
 }
@@ -115,87 +167,23 @@ func isOakhouseProject() bool {
 // addRedisDependencies adds Redis dependencies to go.mod
 func addRedisDependencies() error {
 	fmt.Println("📦 Adding Redis dependencies...")
-
-	// Read current go.mod
-	goModPath := "go.mod"
-	content, err := os.ReadFile(goModPath)
-	if err != nil {
-		return err
-	}
-
-	goModContent := string(content)
-
-	// Check if redis dependency already exists
-	if strings.Contains(goModContent, "github.com/redis/go-redis/v9") {
-		fmt.Println("✓ Redis dependency already exists")
-		return nil
-	}
-
-	// Add Redis dependency to require section
-	requireIndex := strings.Index(goModContent, "require (")
-	if requireIndex == -1 {
-		return fmt.Errorf("could not find require section in go.mod")
-	}
-
-	// Find the end of require section
-	lines := strings.Split(goModContent, "\n")
-	var newLines []string
-	insideRequire := false
-	redisAdded := false
-
-	for _, line := range lines {
-		if strings.Contains(line, "require (") {
-			insideRequire = true
-			newLines = append(newLines, line)
-			continue
-		}
-
-		if insideRequire && strings.Contains(line, ")") && !redisAdded {
-			// Add Redis dependency before closing parenthesis
-			newLines = append(newLines, "\tgithub.com/redis/go-redis/v9 v9.3.0")
-			redisAdded = true
-		}
-
-		newLines = append(newLines, line)
-
-		if insideRequire && strings.Contains(line, ")") {
-			insideRequire = false
-		}
-	}
-
-	// Write updated go.mod
-	updatedContent := strings.Join(newLines, "\n")
-	return os.WriteFile(goModPath, []byte(updatedContent), 0644)
+	return addGoModDependencies("github.com/redis/go-redis/v9 v9.3.0", "github.com/alicebob/miniredis/v2 v2.31.1")
 }
 
 // addRedisEnvConfig adds Redis configuration to .env.example
 func addRedisEnvConfig() error {
 	fmt.Println("⚙️ Adding Redis environment configuration...")
 
-	envExamplePath := ".env.example"
-	content, err := os.ReadFile(envExamplePath)
-	if err != nil {
-		return err
-	}
-
-	envContent := string(content)
-
-	// Check if Redis config already exists
-	if strings.Contains(envContent, "REDIS_URL") {
-		fmt.Println("✓ Redis configuration already exists")
-		return nil
-	}
-
 	// Add Redis configuration
 	redisConfig := `
 # Redis Configuration
 REDIS_URL=redis://localhost:6379
 REDIS_PASSWORD=
 REDIS_DB=0
+REDIS_CACHE_NAMESPACE=cache
 `
 
-	updatedContent := envContent + redisConfig
-	return os.WriteFile(envExamplePath, []byte(updatedContent), 0644)
+	return appendEnvConfig("REDIS_URL", redisConfig)
 }
 
 // createRedisAdapter creates Redis adapter file
@@ -222,102 +210,43 @@ func createRedisAdapter() error {
 	return utils.WriteFile(redisAdapterPath, redisAdapterContent, nil)
 }
 
-// createRedisUtils creates Redis utility file
+// createRedisUtils creates the Redis cache, lock and event bus utilities and their tests
 func createRedisUtils() error {
 	fmt.Println("🔧 Creating Redis utilities...")
 
-	utilDir := "util"
-	redisUtilPath := filepath.Join(utilDir, "redis_util.go")
-
-	// Check if Redis util already exists
-	if _, err := os.Stat(redisUtilPath); err == nil {
-		fmt.Println("✓ Redis utilities already exist")
-		return nil
-	}
-
 	// Get project name from go.mod
 	projectName, err := getProjectName()
 	if err != nil {
 		return err
 	}
 
-	redisUtilContent := fmt.Sprintf(templates.RedisUtilTemplate, projectName)
+	files := []struct{ path, tmpl string }{
+		{filepath.Join("util", "redis_util.go"), templates.RedisUtilTemplate},
+		{filepath.Join("util", "redis_util_test.go"), templates.RedisUtilTestTemplate},
+		{filepath.Join("util", "redis_lock.go"), templates.RedisLockTemplate},
+		{filepath.Join("util", "redis_lock_test.go"), templates.RedisLockTestTemplate},
+		{filepath.Join("util", "eventbus.go"), templates.EventBusTemplate},
+		{filepath.Join("util", "eventbus_test.go"), templates.EventBusTestTemplate},
+	}
+	for _, file := range files {
+		// Keep existing utilities, which may have been customized
+		if utils.FileExists(file.path) {
+			fmt.Printf("✓ %s already exists\n", file.path)
+			continue
+		}
+		if err := utils.WriteFile(file.path, fmt.Sprintf(file.tmpl, projectName), nil); err != nil {
+			return err
+		}
+	}
 
-	return utils.WriteFile(redisUtilPath, redisUtilContent, nil)
+	// Events are published once the surrounding transaction commits
+	return generators.GenerateTxUtil()
 }
 
-// updateConfigForRedis updates the config file to include Redis fields if missing
+// updateConfigForRedis registers the Redis settings section in the config package
 func updateConfigForRedis() error {
 	fmt.Println("🔧 Updating config for Redis...")
-
-	configPath := filepath.Join("config", "env_config.go")
-
-	// Check if file exists
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return fmt.Errorf("config file not found at %s", configPath)
-	}
-
-	content, err := os.ReadFile(configPath)
-	if err != nil {
-		return fmt.Errorf("failed to read config file: %v", err)
-	}
-
-	configStr := string(content)
-
-	// Check if Redis fields already exist
-	if strings.Contains(configStr, "RedisURL") {
-		fmt.Println("✓ Redis configuration already exists in config")
-		return nil
-	}
-
-	// 1. Patch Config struct
-	structInsertIndex := strings.Index(configStr, "type Config struct {")
-	if structInsertIndex == -1 {
-		return fmt.Errorf("could not find Config struct definition")
-	}
-
-	structEndIndex := strings.Index(configStr[structInsertIndex:], "}")
-	if structEndIndex == -1 {
-		return fmt.Errorf("could not find end of Config struct")
-	}
-
-	structBlock := configStr[structInsertIndex : structInsertIndex+structEndIndex]
-	if !strings.Contains(structBlock, "RedisURL") {
-		redisFields := `
-	RedisURL      string
-	RedisPassword string
-	RedisDB       string`
-		configStr = strings.Replace(configStr, structBlock+"}", structBlock+redisFields+"\n}", 1)
-	}
-
-	// 2. Patch LoadConfig() return block
-	loadFuncIndex := strings.Index(configStr, "func LoadConfig() *Config {")
-	if loadFuncIndex == -1 {
-		return fmt.Errorf("could not find LoadConfig function")
-	}
-
-	returnBlockStart := strings.Index(configStr[loadFuncIndex:], "return &Config{")
-	if returnBlockStart == -1 {
-		return fmt.Errorf("could not find return &Config block")
-	}
-
-	startPos := loadFuncIndex + returnBlockStart
-	endPos := strings.Index(configStr[startPos:], "}") + startPos
-	if endPos == -1 {
-		return fmt.Errorf("could not find end of Config return block")
-	}
-
-	// Inject Redis fields
-	redisLines := `
-		RedisURL:      getEnv("REDIS_URL", "localhost:6379"),
-		RedisPassword: getEnv("REDIS_PASSWORD", ""),
-		RedisDB:       getEnv("REDIS_DB", "0"),`
-
-	// Insert above the closing `}` of return block
-	configStr = configStr[:endPos] + redisLines + configStr[endPos:]
-
-	// Write back
-	return utils.WriteFile(configPath, configStr, nil)
+	return writeConfigSection("redis_config.go", templates.RedisConfigTemplate, nil)
 }
 
 // updateMainGoForRedis updates the main.go file to include Redis initialization
@@ -355,7 +284,7 @@ func updateMainGoForRedis() error {
 
 	// Initialize Redis (optional)
 	var redisAdapter *adapter.RedisAdapter
-	if cfg.RedisURL != "" {
+	if cfg.Redis().URL != "" {
 		redisAdapter, err = adapter.NewRedisAdapter(cfg)
 		if err != nil {
 			log.Fatal("Failed to initialize Redis", err)
@@ -374,6 +303,46 @@ func updateMainGoForRedis() error {
 	return utils.WriteFile(mainPath, mainStr, nil)
 }
 
+// updateWireForRedis adds the optional Redis adapter provider to AppSet, since NewAppServer
+// takes the adapter once Redis is integrated
+func updateWireForRedis() error {
+	adapterPath := filepath.Join("adapter", "redis_adapter.go")
+	content, err := os.ReadFile(adapterPath)
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(string(content), "func ProvideRedisAdapter(") {
+		adapterStr := strings.TrimRight(string(content), "\n") + "\n" + templates.RedisProviderTemplate
+		if err := os.WriteFile(adapterPath, []byte(adapterStr), 0644); err != nil {
+			return err
+		}
+	}
+
+	wirePath := filepath.Join("cmd", "wire.go")
+	content, err = os.ReadFile(wirePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	wireStr := string(content)
+	if strings.Contains(wireStr, "adapter.ProvideRedisAdapter") {
+		fmt.Println("✓ wire.go already provides the Redis adapter")
+		return nil
+	}
+
+	providerPattern := "\tNewAppServer,\n"
+	if !strings.Contains(wireStr, providerPattern) {
+		fmt.Println("⚠️ Could not find NewAppServer in the wire set; add adapter.ProvideRedisAdapter to AppSet in cmd/wire.go")
+		return nil
+	}
+	wireStr = strings.Replace(wireStr, providerPattern, "\tadapter.ProvideRedisAdapter,\n"+providerPattern, 1)
+
+	return os.WriteFile(wirePath, []byte(wireStr), 0644)
+}
+
 // updateAppServerForRedis updates the app_server.go file to include Redis adapter
 func updateAppServerForRedis() error {
 	appServerPath := "cmd/app_server.go"
@@ -388,50 +357,154 @@ func updateAppServerForRedis() error {
 
 	appServerStr := string(content)
 
+	projectName, err := getProjectName()
+	if err != nil {
+		return err
+	}
+
 	// Check if Redis is already integrated
 	if strings.Contains(appServerStr, "redisAdapter") {
 		fmt.Println("✓ app_server.go already contains Redis integration")
 		return nil
 	}
 
-	// Update AppServer struct to include Redis adapter
-	structPattern := `type AppServer struct {
-	app *fiber.App
-	cfg *config.Config
-	db  *gorm.DB
-}`
+	appServerStr, err = patchAppServerForRedis(appServerStr, projectName)
+	if err != nil {
+		return err
+	}
 
-	redisStruct := `type AppServer struct {
-	app          *fiber.App
-	cfg          *config.Config
-	db           *gorm.DB
-	redisAdapter *adapter.RedisAdapter
-}`
+	return writeGoFile(appServerPath, appServerStr)
+}
 
-	appServerStr = strings.Replace(appServerStr, structPattern, redisStruct, 1)
+// patchAppServerForRedis threads the Redis adapter through AppServer and registers its
+// health check and shutdown hook when the server supports them
+func patchAppServerForRedis(appServerStr, projectName string) (string, error) {
+	// Update AppServer struct to include Redis adapter
+	structField := regexp.MustCompile(`(?m)^\tdb +\*gorm\.DB\n`)
+	if !structField.MatchString(appServerStr) {
+		return "", fmt.Errorf("could not find db field in AppServer struct")
+	}
+	appServerStr = structField.ReplaceAllStringFunc(appServerStr, func(field string) string {
+		return field + "\tredisAdapter *adapter.RedisAdapter\n"
+	})
 
 	// Update NewAppServer function signature
 	funcPattern := `func NewAppServer(cfg *config.Config, db *gorm.DB) *AppServer {`
 	redisFuncPattern := `func NewAppServer(cfg *config.Config, db *gorm.DB, redisAdapter *adapter.RedisAdapter) *AppServer {`
 	appServerStr = strings.Replace(appServerStr, funcPattern, redisFuncPattern, 1)
 
-	// Update return statement
-	returnPattern := `	return &AppServer{
-		app: app,
-		cfg: cfg,
-		db:  db,
-	}`
+	// Set the adapter in the AppServer literal
+	literalField := regexp.MustCompile(`(?m)^\t\tdb: +db,\n`)
+	appServerStr = literalField.ReplaceAllStringFunc(appServerStr, func(field string) string {
+		return field + "\t\tredisAdapter: redisAdapter,\n"
+	})
+
+	// Report Redis on the readiness probe when the server has a health registry
+	redisHealthCheck := ""
+	if strings.Contains(appServerStr, "server.health.Register(") {
+		redisHealthCheck = `
+	if redisAdapter != nil {
+		server.health.Register("redis", func(ctx context.Context) error {
+			return redisAdapter.GetClient().Ping(ctx).Err()
+		})
+	}
+`
+	}
 
-	redisReturnPattern := `	return &AppServer{
-		app:          app,
-		cfg:          cfg,
-		db:           db,
-		redisAdapter: redisAdapter,
-	}`
+	// Close the Redis connection on shutdown when the server supports lifecycle hooks
+	if strings.Contains(appServerStr, "func (s *AppServer) OnStop(") {
+		appServerStr = strings.Replace(appServerStr, "\n\treturn server\n}", `
+	if redisAdapter != nil {
+		server.OnStop(func(context.Context) error {
+			return redisAdapter.Close()
+		})
+	}
+`+redisHealthCheck+`
 
-	appServerStr = strings.Replace(appServerStr, returnPattern, redisReturnPattern, 1)
+	return server
+}`, 1)
+	}
+
+	return addImport(appServerStr, projectName+"/adapter"), nil
+}
+
+// warnServicesWithoutEvents lists the services that never call util.PublishEvent. Their writes
+// are too varied to patch reliably, so they are reported instead of changed.
+func warnServicesWithoutEvents() error {
+	paths, err := filepath.Glob(filepath.Join("service", "*_service.go"))
+	if err != nil {
+		return err
+	}
+
+	var silent []string
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if strings.Contains(string(content), "util.PublishEvent(") {
+			continue
+		}
+		if match := serviceConstructorPattern.FindStringSubmatch(string(content)); match != nil {
+			silent = append(silent, fmt.Sprintf("%sService (%s)", match[1], path))
+		} else {
+			silent = append(silent, path)
+		}
+	}
 
-	return utils.WriteFile(appServerPath, appServerStr, nil)
+	if len(silent) > 0 {
+		fmt.Println("⚠️ These services were generated before the event bus and publish no events; regenerate them or call util.PublishEvent after their writes:")
+		for _, service := range silent {
+			fmt.Printf("   - %s\n", service)
+		}
+	}
+	return nil
+}
+
+// updateAppServerForEvents publishes service events through the event bus and consumes the
+// events subscribed in cmd/events.go in the background while the server runs
+func updateAppServerForEvents() error {
+	appServerPath := "cmd/app_server.go"
+	content, err := os.ReadFile(appServerPath)
+	if err != nil {
+		return err
+	}
+
+	appServerStr := string(content)
+	if strings.Contains(appServerStr, "util.NewEventBus(") {
+		fmt.Println("✓ app_server.go already runs the event bus")
+		return nil
+	}
+	if !strings.Contains(appServerStr, "func (s *AppServer) Background(") {
+		fmt.Println("⚠️ app_server.go has no background workers; run 'oakhouse upgrade' and integrate Redis again to run the event bus")
+		return nil
+	}
+
+	projectName, err := getProjectName()
+	if err != nil {
+		return err
+	}
+
+	eventsPath := filepath.Join("cmd", "events.go")
+	if !utils.FileExists(eventsPath) {
+		if err := utils.WriteFile(eventsPath, templates.EventHandlersTemplate, map[string]string{"ProjectName": projectName}); err != nil {
+			return err
+		}
+	}
+
+	appServerStr = strings.Replace(appServerStr, "\n\treturn server\n}", `
+	// Publish service events to Redis Streams and consume the subscribed ones
+	if redisAdapter != nil {
+		eventBus := util.NewEventBus(redisAdapter, util.EventBusOptions{})
+		util.SetEventPublisher(eventBus)
+		registerEventHandlers(eventBus)
+		server.Background(eventBus.Run)
+	}
+
+	return server
+}`, 1)
+
+	return writeGoFile(appServerPath, addImport(appServerStr, projectName+"/util"))
 }
 
 // getProjectName extracts project name from go.mod
@@ -450,3 +523,174 @@ func getProjectName() (string, error) {
 
 	return "", fmt.Errorf("could not find module name in go.mod")
 }
+
+// addGoModDependencies adds the given "module version" requirements to the first
+// require block of go.mod, skipping any module that is already required
+func addGoModDependencies(deps ...string) error {
+	goModPath := "go.mod"
+	content, err := os.ReadFile(goModPath)
+	if err != nil {
+		return err
+	}
+
+	goModContent := string(content)
+	for _, dep := range deps {
+		if module := strings.Fields(dep)[0]; strings.Contains(goModContent, module+" ") {
+			fmt.Printf("✓ %s already exists\n", module)
+		}
+	}
+
+	goModContent, err = addGoModRequires(goModContent, deps...)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(goModPath, []byte(goModContent), 0644)
+}
+
+// addGoModRequires adds "module version" requirements missing from go.mod content
+// to its first require block
+func addGoModRequires(goModContent string, deps ...string) (string, error) {
+	var missing []string
+	for _, dep := range deps {
+		module := strings.Fields(dep)[0]
+		if strings.Contains(goModContent, module+" ") {
+			continue
+		}
+		missing = append(missing, "\t"+dep)
+	}
+	if len(missing) == 0 {
+		return goModContent, nil
+	}
+
+	if !strings.Contains(goModContent, "require (") {
+		return "", fmt.Errorf("could not find require section in go.mod")
+	}
+
+	// Insert the dependencies before the closing parenthesis of the first require block
+	lines := strings.Split(goModContent, "\n")
+	var newLines []string
+	insideRequire := false
+	added := false
+
+	for _, line := range lines {
+		if strings.Contains(line, "require (") {
+			insideRequire = true
+			newLines = append(newLines, line)
+			continue
+		}
+
+		if insideRequire && strings.Contains(line, ")") && !added {
+			newLines = append(newLines, missing...)
+			added = true
+		}
+
+		newLines = append(newLines, line)
+
+		if insideRequire && strings.Contains(line, ")") {
+			insideRequire = false
+		}
+	}
+
+	return strings.Join(newLines, "\n"), nil
+}
+
+// appendEnvConfig appends a configuration block to .env.example unless the marker key is already present
+func appendEnvConfig(marker, block string) error {
+	envExamplePath := ".env.example"
+	content, err := os.ReadFile(envExamplePath)
+	if err != nil {
+		return err
+	}
+
+	envContent := string(content)
+	if strings.Contains(envContent, marker) {
+		fmt.Printf("✓ %s configuration already exists\n", marker)
+		return nil
+	}
+
+	return os.WriteFile(envExamplePath, []byte(envContent+block), 0644)
+}
+
+// ensureConfigLoader adds the tag based configuration loader to projects generated
+// before it existed, so integrations can register their settings sections
+func ensureConfigLoader() error {
+	loaderPath := filepath.Join("config", "loader.go")
+	if utils.FileExists(loaderPath) {
+		return nil
+	}
+
+	configPath := filepath.Join("config", "env_config.go")
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("config file not found at %s", configPath)
+	}
+	if strings.Contains(string(content), "func getEnv(") {
+		return fmt.Errorf("%s still uses getEnv; regenerate it from the current template so it loads settings with config.Load", configPath)
+	}
+
+	if err := addGoModDependencies("gopkg.in/yaml.v3 v3.0.1"); err != nil {
+		return err
+	}
+	return utils.WriteFile(loaderPath, templates.ConfigLoaderTemplate, nil)
+}
+
+// writeConfigSection creates an integration's settings section in the config package if missing
+func writeConfigSection(filename, tmpl string, data interface{}) error {
+	if err := ensureConfigLoader(); err != nil {
+		return err
+	}
+
+	sectionPath := filepath.Join("config", filename)
+	if utils.FileExists(sectionPath) {
+		fmt.Printf("✓ %s already exists\n", sectionPath)
+		return nil
+	}
+
+	return utils.WriteFile(sectionPath, tmpl, data)
+}
+
+// addImport adds an import path to the import block of a Go source file if missing.
+// Standard library packages go to the top of the block, everything else to the bottom.
+func addImport(src, importPath string) string {
+	quoted := fmt.Sprintf("%q", importPath)
+	if strings.Contains(src, quoted) {
+		return src
+	}
+
+	start := strings.Index(src, "import (")
+	if start == -1 {
+		return strings.Replace(src, "\nimport ", "\nimport "+quoted+"\nimport ", 1)
+	}
+
+	projectName, _ := getProjectName()
+	if isStdlibImport(importPath, projectName) {
+		rest := src[start+len("import ("):]
+		// Keep standard library imports in their own group when the block starts with others
+		first := strings.Fields(strings.SplitN(strings.TrimLeft(rest, "\n"), "\n", 2)[0])
+		if len(first) > 0 && !isStdlibImport(strings.Trim(first[len(first)-1], `"`), projectName) {
+			return src[:start] + "import (\n\t" + quoted + "\n" + rest
+		}
+		return src[:start] + "import (\n\t" + quoted + rest
+	}
+
+	end := start + strings.Index(src[start:], "\n)")
+	return src[:end] + "\n\t" + quoted + src[end:]
+}
+
+// isStdlibImport reports whether importPath belongs to the standard library rather than
+// the project or a third-party module
+func isStdlibImport(importPath, projectName string) bool {
+	if projectName != "" && strings.HasPrefix(importPath, projectName+"/") {
+		return false
+	}
+	return !strings.Contains(strings.Split(importPath, "/")[0], ".")
+}
+
+// writeGoFile writes patched Go source back to disk, gofmt-ing it when it parses
+func writeGoFile(path, src string) error {
+	if formatted, err := format.Source([]byte(src)); err == nil {
+		src = string(formatted)
+	}
+	return os.WriteFile(path, []byte(src), 0644)
+}
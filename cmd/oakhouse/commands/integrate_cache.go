@@ -0,0 +1,156 @@
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/generators"
+	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/templates"
+	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/utils"
+	"github.com/spf13/cobra"
+)
+
+// integrateCacheCmd creates the 'integrate cache' subcommand for caching resource repositories in Redis
+func integrateCacheCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "cache [resources...]",
+		Short: "Cache resource repository reads in Redis",
+		Long: `Wrap the repositories of existing resources with a Redis cache-aside decorator.
+
+FindByID, FindAll, Count and the paginated finds are cached, keyed by the SQL their scopes
+produce, and invalidated by tag when the resource is created, updated or deleted. Requires
+the Redis integration. New resources can be generated cached with 'generate resource --cache'.
+
+Examples:
+  oakhouse integrate cache User
+  oakhouse integrate cache User Device`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := integrateCache(args); err != nil {
+				fmt.Printf("❌ Error integrating cache: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println("✅ Cache integration completed successfully!")
+		},
+	}
+}
+
+// integrateCache sets up the query cache and wraps the given resources' repositories with it
+func integrateCache(resources []string) error {
+	if err := setupQueryCache(); err != nil {
+		return err
+	}
+
+	for _, resource := range resources {
+		fmt.Printf("🔧 Caching %s repository...\n", resource)
+		files, err := generators.CacheResource(resource)
+		if err != nil {
+			return fmt.Errorf("failed to cache %s: %v", resource, err)
+		}
+		for _, file := range files {
+			fmt.Printf("✓ %s\n", file)
+		}
+	}
+
+	fmt.Println("\n📋 Next steps:")
+	fmt.Println("1. Run 'go mod tidy' to download the singleflight dependency")
+	fmt.Println("2. Set QUERY_CACHE_TTL in your .env file (default 5m)")
+	fmt.Println("3. Cache more resources with 'oakhouse integrate cache <Resource>'")
+
+	return nil
+}
+
+// setupQueryCache adds the project-wide pieces of the query cache. It is shared by
+// 'integrate cache' and 'generate resource --cache'.
+func setupQueryCache() error {
+	// Check if we're in an Oakhouse project
+	if !isOakhouseProject() {
+		return fmt.Errorf("not in an Oakhouse project directory. Please run this command from your project root")
+	}
+	if !utils.FileExists(filepath.Join("adapter", "redis_adapter.go")) {
+		return fmt.Errorf("caching requires Redis. Run 'oakhouse integrate redis' first")
+	}
+
+	fmt.Println("🚀 Integrating the query cache...")
+
+	// 1. Update go.mod with the singleflight dependency
+	fmt.Println("📦 Adding cache dependencies...")
+	if err := addGoModDependencies("golang.org/x/sync v0.6.0"); err != nil {
+		return fmt.Errorf("failed to add cache dependencies: %v", err)
+	}
+
+	// 2. Update .env.example with the cache TTL
+	fmt.Println("⚙️ Adding cache environment configuration...")
+	if err := appendEnvConfig("QUERY_CACHE_TTL", `
+# Query Cache Configuration
+QUERY_CACHE_TTL=5m
+`); err != nil {
+		return fmt.Errorf("failed to add cache environment configuration: %v", err)
+	}
+
+	// 3. Update config to include the cache fields
+	fmt.Println("🔧 Updating config for cache...")
+	if err := writeConfigSection("cache_config.go", templates.CacheConfigTemplate, nil); err != nil {
+		return fmt.Errorf("failed to update config for cache: %v", err)
+	}
+
+	// 4. Create the cache helpers
+	if err := generators.GenerateQueryCache(); err != nil {
+		return fmt.Errorf("failed to create cache utilities: %v", err)
+	}
+
+	// 5. Enable the cache in app_server.go
+	if err := updateAppServerForCache(); err != nil {
+		return fmt.Errorf("failed to update app_server.go for cache: %v", err)
+	}
+
+	return nil
+}
+
+// updateAppServerForCache enables the cached repositories when a Redis adapter is configured
+func updateAppServerForCache() error {
+	appServerPath := "cmd/app_server.go"
+	content, err := os.ReadFile(appServerPath)
+	if err != nil {
+		return fmt.Errorf("app_server.go not found at %s", appServerPath)
+	}
+
+	appServerStr := string(content)
+
+	// Check if the cache is already enabled
+	if strings.Contains(appServerStr, "route.UseQueryCache(") {
+		fmt.Println("✓ app_server.go already enables the query cache")
+		return nil
+	}
+
+	appServerStr, err = patchAppServerForCache(appServerStr)
+	if err != nil {
+		return fmt.Errorf("%v in %s", err, appServerPath)
+	}
+
+	return writeGoFile(appServerPath, appServerStr)
+}
+
+// patchAppServerForCache creates the query cache on the Redis adapter ahead of the AppServer literal
+func patchAppServerForCache(appServerStr string) (string, error) {
+	serverPattern := "\tserver := &AppServer{\n"
+	if !strings.Contains(appServerStr, "redisAdapter *adapter.RedisAdapter") || !strings.Contains(appServerStr, serverPattern) {
+		return "", fmt.Errorf("could not find the Redis adapter in NewAppServer")
+	}
+
+	appServerStr = strings.Replace(appServerStr, serverPattern, `	// Cache the repositories of resources generated with --cache
+	if redisAdapter != nil {
+		route.UseQueryCache(util.NewQueryCache(util.NewCacheManager(redisAdapter, cfg.Redis().CacheNamespace), cfg.Cache().TTL))
+	}
+
+`+serverPattern, 1)
+
+	projectName, err := getProjectName()
+	if err != nil {
+		return "", err
+	}
+	appServerStr = addImport(appServerStr, projectName+"/route")
+	return addImport(appServerStr, projectName+"/util"), nil
+}
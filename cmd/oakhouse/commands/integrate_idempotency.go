@@ -0,0 +1,247 @@
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/templates"
+	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/utils"
+	"github.com/spf13/cobra"
+)
+
+// integrateIdempotencyCmd creates the 'integrate idempotency' subcommand for adding Idempotency-Key support
+func integrateIdempotencyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "idempotency",
+		Short: "Integrate Idempotency-Key support for POST endpoints",
+		Long: `Add Idempotency-Key support to your Oakhouse project.
+
+Retried POST requests that carry an Idempotency-Key header get the stored response of the
+first attempt instead of creating duplicates. Keys are kept in Redis when it is integrated
+and configured, otherwise in an idempotency_keys table created by a new migration.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := integrateIdempotency(); err != nil {
+				fmt.Printf("❌ Error integrating idempotency: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println("✅ Idempotency integration completed successfully!")
+		},
+	}
+}
+
+// integrateIdempotency adds the Idempotency-Key middleware to the current project
+func integrateIdempotency() error {
+	// Check if we're in an Oakhouse project
+	if !isOakhouseProject() {
+		return fmt.Errorf("not in an Oakhouse project directory. Please run this command from your project root")
+	}
+
+	fmt.Println("🚀 Integrating Idempotency-Key support...")
+
+	// 1. Update .env.example with idempotency configuration
+	if err := addIdempotencyEnvConfig(); err != nil {
+		return fmt.Errorf("failed to add idempotency environment configuration: %v", err)
+	}
+
+	// 2. Update config to include idempotency fields
+	if err := updateConfigForIdempotency(); err != nil {
+		return fmt.Errorf("failed to update config for idempotency: %v", err)
+	}
+
+	// 3. Create the key stores and the middleware
+	if err := createIdempotencyFiles(); err != nil {
+		return fmt.Errorf("failed to create idempotency files: %v", err)
+	}
+
+	// 4. Create the idempotency_keys table migration
+	if err := createIdempotencyMigration(); err != nil {
+		return fmt.Errorf("failed to create idempotency migration: %v", err)
+	}
+
+	// 5. Register the middleware in app_server.go
+	if err := updateAppServerForIdempotency(); err != nil {
+		return fmt.Errorf("failed to update app_server.go for idempotency: %v", err)
+	}
+
+	fmt.Println("\n📋 Next steps:")
+	fmt.Println("1. Apply the new migration unless Redis stores the keys")
+	fmt.Println("2. Send an Idempotency-Key header with POST requests that may be retried")
+	fmt.Println("3. Set IDEMPOTENCY_REQUIRED=true to reject POST requests without one")
+
+	return nil
+}
+
+// addIdempotencyEnvConfig adds idempotency configuration to .env.example
+func addIdempotencyEnvConfig() error {
+	fmt.Println("⚙️ Adding idempotency environment configuration...")
+
+	idempotencyConfig := `
+# Idempotency Configuration
+IDEMPOTENCY_METHODS=POST
+IDEMPOTENCY_TTL=24h
+IDEMPOTENCY_LOCK_TTL=1m
+IDEMPOTENCY_WAIT=5s
+IDEMPOTENCY_REQUIRED=false
+`
+
+	return appendEnvConfig("IDEMPOTENCY_TTL", idempotencyConfig)
+}
+
+// updateConfigForIdempotency registers the idempotency settings section in the config package
+func updateConfigForIdempotency() error {
+	fmt.Println("🔧 Updating config for idempotency...")
+	return writeConfigSection("idempotency_config.go", templates.IdempotencyConfigTemplate, nil)
+}
+
+// createIdempotencyFiles creates the key stores and the Idempotency-Key middleware
+func createIdempotencyFiles() error {
+	fmt.Println("🔧 Creating idempotency middleware and stores...")
+
+	projectName, err := getProjectName()
+	if err != nil {
+		return err
+	}
+
+	files := map[string]string{
+		filepath.Join("util", "idempotency.go"):       templates.IdempotencyUtilTemplate,
+		filepath.Join("middleware", "idempotency.go"): templates.IdempotencyMiddlewareTemplate,
+	}
+	if utils.FileExists(filepath.Join("adapter", "redis_adapter.go")) {
+		files[filepath.Join("util", "idempotency_redis.go")] = templates.IdempotencyRedisUtilTemplate
+	}
+
+	for path, tmpl := range files {
+		if utils.FileExists(path) {
+			fmt.Printf("✓ %s already exists\n", path)
+			continue
+		}
+		if err := utils.WriteFile(path, tmpl, map[string]string{"ProjectName": projectName}); err != nil {
+			return err
+		}
+	}
+
+	return createCurrentUserUtil()
+}
+
+// createIdempotencyMigration writes the migration pair creating the idempotency_keys table
+func createIdempotencyMigration() error {
+	existing, _ := filepath.Glob(filepath.Join("migrations", "*_create_idempotency_keys.up.sql"))
+	if len(existing) > 0 {
+		fmt.Printf("✓ %s already exists\n", existing[0])
+		return nil
+	}
+
+	prefix := filepath.Join("migrations", time.Now().UTC().Format("20060102150405")+"_create_idempotency_keys")
+	if err := utils.WriteFile(prefix+".up.sql", templates.IdempotencyMigrationUpTemplate, nil); err != nil {
+		return err
+	}
+	return utils.WriteFile(prefix+".down.sql", templates.IdempotencyMigrationDownTemplate, nil)
+}
+
+// updateAppServerForIdempotency registers the Idempotency-Key middleware after the custom middleware
+func updateAppServerForIdempotency() error {
+	appServerPath := "cmd/app_server.go"
+	content, err := os.ReadFile(appServerPath)
+	if err != nil {
+		return fmt.Errorf("app_server.go not found at %s", appServerPath)
+	}
+
+	appServerStr := string(content)
+
+	// Check if the middleware is already registered
+	if strings.Contains(appServerStr, "middleware.Idempotency(") {
+		fmt.Println("✓ app_server.go already contains idempotency middleware")
+		return nil
+	}
+
+	appServerStr, err = patchAppServerForIdempotency(appServerStr)
+	if err != nil {
+		return fmt.Errorf("%v in %s", err, appServerPath)
+	}
+
+	return writeGoFile(appServerPath, appServerStr)
+}
+
+// gormIdempotencyStorePattern is the store selection line patched when Redis is integrated later
+const gormIdempotencyStorePattern = "\tvar idempotencyStore util.IdempotencyStore = util.NewGormIdempotencyStore(db)\n"
+
+// patchAppServerForIdempotency registers the middleware with the Redis store when the server has a
+// Redis adapter, and purges expired database keys when the server runs background workers
+func patchAppServerForIdempotency(appServerStr string) (string, error) {
+	if !strings.Contains(appServerStr, "\tapp.Use(middleware.AuthMiddleware())\n") {
+		return "", fmt.Errorf("could not find custom middleware registration")
+	}
+	// Rate limited requests are rejected before a key is stored, and keys are scoped to the session's user
+	customPattern := middlewareAnchor(appServerStr, rateLimitMiddlewarePattern, sessionMiddlewarePattern)
+
+	appServerStr = strings.Replace(appServerStr, customPattern, customPattern+`
+	// Replay retried requests that carry an Idempotency-Key
+`+gormIdempotencyStorePattern+`	app.Use(middleware.Idempotency(idempotencyStore, cfg.Idempotency()))
+`, 1)
+
+	if strings.Contains(appServerStr, "redisAdapter *adapter.RedisAdapter") {
+		appServerStr = patchAppServerForRedisIdempotency(appServerStr)
+	}
+
+	if strings.Contains(appServerStr, "func (s *AppServer) Background(") {
+		appServerStr = strings.Replace(appServerStr, "\n\treturn server\n}", `
+	// Delete expired keys from the database store
+	if store, ok := idempotencyStore.(*util.GormIdempotencyStore); ok {
+		server.Background(func(ctx context.Context) {
+			store.PurgeExpired(ctx, time.Hour)
+		})
+	}
+
+	return server
+}`, 1)
+		appServerStr = addImport(appServerStr, "context")
+		appServerStr = addImport(appServerStr, "time")
+	}
+
+	projectName, err := getProjectName()
+	if err != nil {
+		return "", err
+	}
+	appServerStr = addImport(appServerStr, projectName+"/middleware")
+	return addImport(appServerStr, projectName+"/util"), nil
+}
+
+// patchAppServerForRedisIdempotency keeps Idempotency-Keys in Redis whenever it is configured
+func patchAppServerForRedisIdempotency(appServerStr string) string {
+	return strings.Replace(appServerStr, gormIdempotencyStorePattern, gormIdempotencyStorePattern+`	if redisAdapter != nil {
+		idempotencyStore = util.NewRedisIdempotencyStore(redisAdapter.GetClient())
+	}
+`, 1)
+}
+
+// useRedisForIdempotency switches an existing idempotency integration to the Redis store
+func useRedisForIdempotency() error {
+	projectName, err := getProjectName()
+	if err != nil {
+		return err
+	}
+
+	redisStorePath := filepath.Join("util", "idempotency_redis.go")
+	if !utils.FileExists(redisStorePath) {
+		if err := utils.WriteFile(redisStorePath, templates.IdempotencyRedisUtilTemplate, map[string]string{"ProjectName": projectName}); err != nil {
+			return err
+		}
+	}
+
+	appServerPath := "cmd/app_server.go"
+	content, err := os.ReadFile(appServerPath)
+	if err != nil {
+		return err
+	}
+
+	appServerStr := string(content)
+	if strings.Contains(appServerStr, "NewRedisIdempotencyStore") || !strings.Contains(appServerStr, gormIdempotencyStorePattern) {
+		return nil
+	}
+
+	return writeGoFile(appServerPath, patchAppServerForRedisIdempotency(appServerStr))
+}
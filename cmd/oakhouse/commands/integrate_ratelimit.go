@@ -0,0 +1,217 @@
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/templates"
+	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/utils"
+	"github.com/spf13/cobra"
+)
+
+// integrateRateLimitCmd creates the 'integrate ratelimit' subcommand for adding rate limiting
+func integrateRateLimitCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "ratelimit",
+		Short: "Integrate per-client rate limiting",
+		Long: `Add rate limiting to your Oakhouse project.
+
+Requests are counted per client IP, API key or user with a sliding window or token bucket,
+and rejected with 429 and Retry-After once a client exceeds its limit. Counters are kept in
+Redis when it is integrated and configured, so every instance shares them, otherwise in memory.
+Route groups get their own limits with RATE_LIMIT_ROUTES.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := integrateRateLimit(); err != nil {
+				fmt.Printf("❌ Error integrating rate limiting: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println("✅ Rate limit integration completed successfully!")
+		},
+	}
+}
+
+// integrateRateLimit adds the rate limiting middleware to the current project
+func integrateRateLimit() error {
+	// Check if we're in an Oakhouse project
+	if !isOakhouseProject() {
+		return fmt.Errorf("not in an Oakhouse project directory. Please run this command from your project root")
+	}
+
+	fmt.Println("🚀 Integrating rate limiting...")
+
+	// 1. Update .env.example with rate limit configuration
+	if err := addRateLimitEnvConfig(); err != nil {
+		return fmt.Errorf("failed to add rate limit environment configuration: %v", err)
+	}
+
+	// 2. Update config to include rate limit fields
+	if err := updateConfigForRateLimit(); err != nil {
+		return fmt.Errorf("failed to update config for rate limiting: %v", err)
+	}
+
+	// 3. Create the limiters and the middleware
+	if err := createRateLimitFiles(); err != nil {
+		return fmt.Errorf("failed to create rate limit files: %v", err)
+	}
+
+	// 4. Register the middleware in app_server.go
+	if err := updateAppServerForRateLimit(); err != nil {
+		return fmt.Errorf("failed to update app_server.go for rate limiting: %v", err)
+	}
+
+	fmt.Println("\n📋 Next steps:")
+	fmt.Println("1. Set RATE_LIMIT_REQUESTS and RATE_LIMIT_WINDOW in your .env file")
+	fmt.Println("2. Add stricter limits for route groups with RATE_LIMIT_ROUTES=/api/v1/auth=5/1m")
+	fmt.Println("3. Integrate Redis to share the limits between instances")
+
+	return nil
+}
+
+// addRateLimitEnvConfig adds rate limit configuration to .env.example
+func addRateLimitEnvConfig() error {
+	fmt.Println("⚙️ Adding rate limit environment configuration...")
+
+	rateLimitConfig := `
+# Rate Limit Configuration
+RATE_LIMIT_REQUESTS=100
+RATE_LIMIT_WINDOW=1m
+RATE_LIMIT_ALGORITHM=sliding-window
+RATE_LIMIT_KEY_BY=ip
+RATE_LIMIT_API_KEY_HEADER=X-API-Key
+RATE_LIMIT_ROUTES=
+`
+
+	return appendEnvConfig("RATE_LIMIT_WINDOW", rateLimitConfig)
+}
+
+// updateConfigForRateLimit registers the rate limit settings section in the config package
+func updateConfigForRateLimit() error {
+	fmt.Println("🔧 Updating config for rate limiting...")
+	return writeConfigSection("ratelimit_config.go", templates.RateLimitConfigTemplate, nil)
+}
+
+// createRateLimitFiles creates the limiters and the rate limiting middleware
+func createRateLimitFiles() error {
+	fmt.Println("🔧 Creating rate limit middleware and limiters...")
+
+	projectName, err := getProjectName()
+	if err != nil {
+		return err
+	}
+
+	files := map[string]string{
+		filepath.Join("util", "ratelimit.go"):       templates.RateLimitUtilTemplate,
+		filepath.Join("middleware", "ratelimit.go"): templates.RateLimitMiddlewareTemplate,
+	}
+	if utils.FileExists(filepath.Join("adapter", "redis_adapter.go")) {
+		files[filepath.Join("util", "ratelimit_redis.go")] = templates.RateLimitRedisUtilTemplate
+	}
+
+	for path, tmpl := range files {
+		if utils.FileExists(path) {
+			fmt.Printf("✓ %s already exists\n", path)
+			continue
+		}
+		if err := utils.WriteFile(path, tmpl, map[string]string{"ProjectName": projectName}); err != nil {
+			return err
+		}
+	}
+
+	return createCurrentUserUtil()
+}
+
+// updateAppServerForRateLimit registers the rate limiting middleware after the custom middleware
+func updateAppServerForRateLimit() error {
+	appServerPath := "cmd/app_server.go"
+	content, err := os.ReadFile(appServerPath)
+	if err != nil {
+		return fmt.Errorf("app_server.go not found at %s", appServerPath)
+	}
+
+	appServerStr := string(content)
+
+	// Check if the middleware is already registered
+	if strings.Contains(appServerStr, "middleware.RateLimit(") {
+		fmt.Println("✓ app_server.go already contains rate limit middleware")
+		return nil
+	}
+
+	appServerStr, err = patchAppServerForRateLimit(appServerStr)
+	if err != nil {
+		return fmt.Errorf("%v in %s", err, appServerPath)
+	}
+
+	return writeGoFile(appServerPath, appServerStr)
+}
+
+const (
+	// memoryRateLimiterPattern is the limiter selection line patched when Redis is integrated later
+	memoryRateLimiterPattern = "\tvar rateLimiter util.RateLimiter = util.NewMemoryRateLimiter()\n"
+
+	// rateLimitMiddlewarePattern registers the middleware; the idempotency middleware is added after it
+	rateLimitMiddlewarePattern = "\tapp.Use(middleware.RateLimit(rateLimiter, cfg.RateLimit()))\n"
+)
+
+// patchAppServerForRateLimit registers the middleware right after authentication, so limits can be
+// keyed by user, with the Redis limiter when the server has a Redis adapter
+func patchAppServerForRateLimit(appServerStr string) (string, error) {
+	if !strings.Contains(appServerStr, "\tapp.Use(middleware.AuthMiddleware())\n") {
+		return "", fmt.Errorf("could not find custom middleware registration")
+	}
+	// Sessions identify the user before requests are counted
+	customPattern := middlewareAnchor(appServerStr, sessionMiddlewarePattern)
+
+	appServerStr = strings.Replace(appServerStr, customPattern, customPattern+`
+	// Limit request rates per client
+`+memoryRateLimiterPattern+rateLimitMiddlewarePattern, 1)
+
+	if strings.Contains(appServerStr, "redisAdapter *adapter.RedisAdapter") {
+		appServerStr = patchAppServerForRedisRateLimit(appServerStr)
+	}
+
+	projectName, err := getProjectName()
+	if err != nil {
+		return "", err
+	}
+	appServerStr = addImport(appServerStr, projectName+"/middleware")
+	return addImport(appServerStr, projectName+"/util"), nil
+}
+
+// patchAppServerForRedisRateLimit shares the counters through Redis whenever it is configured
+func patchAppServerForRedisRateLimit(appServerStr string) string {
+	return strings.Replace(appServerStr, memoryRateLimiterPattern, memoryRateLimiterPattern+`	if redisAdapter != nil {
+		rateLimiter = util.NewRedisRateLimiter(redisAdapter.GetClient())
+	}
+`, 1)
+}
+
+// useRedisForRateLimit switches an existing rate limit integration to the Redis limiter
+func useRedisForRateLimit() error {
+	projectName, err := getProjectName()
+	if err != nil {
+		return err
+	}
+
+	redisLimiterPath := filepath.Join("util", "ratelimit_redis.go")
+	if !utils.FileExists(redisLimiterPath) {
+		if err := utils.WriteFile(redisLimiterPath, templates.RateLimitRedisUtilTemplate, map[string]string{"ProjectName": projectName}); err != nil {
+			return err
+		}
+	}
+
+	appServerPath := "cmd/app_server.go"
+	content, err := os.ReadFile(appServerPath)
+	if err != nil {
+		return err
+	}
+
+	appServerStr := string(content)
+	if strings.Contains(appServerStr, "NewRedisRateLimiter") || !strings.Contains(appServerStr, memoryRateLimiterPattern) {
+		return nil
+	}
+
+	return writeGoFile(appServerPath, patchAppServerForRedisRateLimit(appServerStr))
+}
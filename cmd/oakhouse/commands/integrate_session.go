@@ -0,0 +1,250 @@
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/templates"
+	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/utils"
+	"github.com/spf13/cobra"
+)
+
+// integrateSessionCmd creates the 'integrate session' subcommand for adding cookie sessions
+func integrateSessionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "session",
+		Short: "Integrate server-side cookie sessions",
+		Long: `Add server-side sessions identified by an HTTP-only cookie to your Oakhouse project.
+
+Sessions are kept in Redis when it is integrated and configured, otherwise in memory. Handlers
+log users in and out through the session manager, which rotates the session ID on login, and
+read the current user with util.CurrentUserID.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := integrateSession(); err != nil {
+				fmt.Printf("❌ Error integrating sessions: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println("✅ Session integration completed successfully!")
+		},
+	}
+}
+
+// integrateSession adds the session middleware to the current project
+func integrateSession() error {
+	// Check if we're in an Oakhouse project
+	if !isOakhouseProject() {
+		return fmt.Errorf("not in an Oakhouse project directory. Please run this command from your project root")
+	}
+
+	fmt.Println("🚀 Integrating cookie sessions...")
+
+	// 1. Update .env.example and the development profile with session configuration
+	if err := addSessionEnvConfig(); err != nil {
+		return fmt.Errorf("failed to add session environment configuration: %v", err)
+	}
+
+	// 2. Update config to include session fields
+	if err := updateConfigForSession(); err != nil {
+		return fmt.Errorf("failed to update config for sessions: %v", err)
+	}
+
+	// 3. Create the session manager, storage and middleware
+	if err := createSessionFiles(); err != nil {
+		return fmt.Errorf("failed to create session files: %v", err)
+	}
+
+	// 4. Register the middleware in app_server.go
+	if err := updateAppServerForSession(); err != nil {
+		return fmt.Errorf("failed to update app_server.go for sessions: %v", err)
+	}
+
+	fmt.Println("\n📋 Next steps:")
+	fmt.Println("1. Call sessions.Login(c, userID) in your login handler and sessions.Logout(c) to log out")
+	fmt.Println("2. Read the current user with util.CurrentUserID(c)")
+	fmt.Println("3. Protect route groups with middleware.RequireLogin()")
+
+	return nil
+}
+
+// addSessionEnvConfig adds session configuration to .env.example. Cookies are secure by
+// default, so the development profile allows them over plain HTTP; the setting stays commented
+// out in .env.example because .env takes precedence over the profile.
+func addSessionEnvConfig() error {
+	fmt.Println("⚙️ Adding session environment configuration...")
+
+	sessionConfig := `
+# Session Configuration
+SESSION_EXPIRATION=24h
+SESSION_COOKIE_NAME=session_id
+SESSION_COOKIE_DOMAIN=
+# SESSION_COOKIE_SECURE=true
+SESSION_COOKIE_SAME_SITE=Lax
+SESSION_COOKIE_SESSION_ONLY=false
+`
+	if err := appendEnvConfig("SESSION_COOKIE_NAME", sessionConfig); err != nil {
+		return err
+	}
+
+	profilePath := filepath.Join("config", "development.yaml")
+	content, err := os.ReadFile(profilePath)
+	if err != nil || strings.Contains(string(content), "SESSION_COOKIE_SECURE") {
+		return nil
+	}
+	profile := strings.TrimRight(string(content), "\n") + "\nSESSION_COOKIE_SECURE: false\n"
+	return os.WriteFile(profilePath, []byte(profile), 0644)
+}
+
+// updateConfigForSession registers the session settings section in the config package
+func updateConfigForSession() error {
+	fmt.Println("🔧 Updating config for sessions...")
+	return writeConfigSection("session_config.go", templates.SessionConfigTemplate, nil)
+}
+
+// createSessionFiles creates the session manager, its Redis storage and the session middleware
+func createSessionFiles() error {
+	fmt.Println("🔧 Creating session manager and middleware...")
+
+	projectName, err := getProjectName()
+	if err != nil {
+		return err
+	}
+
+	files := map[string]string{
+		filepath.Join("util", "session.go"):       templates.SessionUtilTemplate,
+		filepath.Join("middleware", "session.go"): templates.SessionMiddlewareTemplate,
+		filepath.Join("route", "session.go"):      templates.SessionRouteTemplate,
+	}
+	if utils.FileExists(filepath.Join("adapter", "redis_adapter.go")) {
+		files[filepath.Join("util", "session_redis.go")] = templates.SessionRedisUtilTemplate
+	}
+
+	for path, tmpl := range files {
+		if utils.FileExists(path) {
+			fmt.Printf("✓ %s already exists\n", path)
+			continue
+		}
+		if err := utils.WriteFile(path, tmpl, map[string]string{"ProjectName": projectName}); err != nil {
+			return err
+		}
+	}
+
+	return createCurrentUserUtil()
+}
+
+// createCurrentUserUtil writes util/user.go, shared by the session, rate limit and idempotency middleware
+func createCurrentUserUtil() error {
+	userPath := filepath.Join("util", "user.go")
+	if utils.FileExists(userPath) {
+		return nil
+	}
+	return utils.WriteFile(userPath, templates.CurrentUserUtilTemplate, nil)
+}
+
+// updateAppServerForSession registers the session middleware after the custom middleware
+func updateAppServerForSession() error {
+	appServerPath := "cmd/app_server.go"
+	content, err := os.ReadFile(appServerPath)
+	if err != nil {
+		return fmt.Errorf("app_server.go not found at %s", appServerPath)
+	}
+
+	appServerStr := string(content)
+
+	// Check if the middleware is already registered
+	if strings.Contains(appServerStr, "middleware.Session(") {
+		fmt.Println("✓ app_server.go already contains session middleware")
+		return nil
+	}
+
+	appServerStr, err = patchAppServerForSession(appServerStr)
+	if err != nil {
+		return fmt.Errorf("%v in %s", err, appServerPath)
+	}
+
+	return writeGoFile(appServerPath, appServerStr)
+}
+
+const (
+	// sessionStoragePattern is the storage declaration patched when Redis is integrated later
+	sessionStoragePattern = "\tvar sessionStorage fiber.Storage\n"
+
+	// sessionMiddlewarePattern registers the middleware; rate limiting and idempotency are added after it
+	sessionMiddlewarePattern = "\tapp.Use(middleware.Session(sessions))\n"
+)
+
+// patchAppServerForSession registers the middleware right after authentication, with the Redis
+// storage when the server has a Redis adapter
+func patchAppServerForSession(appServerStr string) (string, error) {
+	customPattern := "\tapp.Use(middleware.AuthMiddleware())\n"
+	if !strings.Contains(appServerStr, customPattern) {
+		return "", fmt.Errorf("could not find custom middleware registration")
+	}
+
+	appServerStr = strings.Replace(appServerStr, customPattern, customPattern+`
+	// Cookie sessions, kept in memory unless Redis is configured
+`+sessionStoragePattern+`	sessions := util.NewSessionManager(cfg.Session(), sessionStorage)
+	route.UseSessions(sessions)
+`+sessionMiddlewarePattern, 1)
+
+	if strings.Contains(appServerStr, "redisAdapter *adapter.RedisAdapter") {
+		appServerStr = patchAppServerForRedisSession(appServerStr)
+	}
+
+	projectName, err := getProjectName()
+	if err != nil {
+		return "", err
+	}
+	appServerStr = addImport(appServerStr, projectName+"/middleware")
+	appServerStr = addImport(appServerStr, projectName+"/route")
+	return addImport(appServerStr, projectName+"/util"), nil
+}
+
+// patchAppServerForRedisSession keeps sessions in Redis whenever it is configured
+func patchAppServerForRedisSession(appServerStr string) string {
+	return strings.Replace(appServerStr, sessionStoragePattern, sessionStoragePattern+`	if redisAdapter != nil {
+		sessionStorage = util.NewRedisSessionStorage(redisAdapter.GetClient())
+	}
+`, 1)
+}
+
+// useRedisForSessions switches an existing session integration to the Redis storage
+func useRedisForSessions() error {
+	projectName, err := getProjectName()
+	if err != nil {
+		return err
+	}
+
+	redisStoragePath := filepath.Join("util", "session_redis.go")
+	if !utils.FileExists(redisStoragePath) {
+		if err := utils.WriteFile(redisStoragePath, templates.SessionRedisUtilTemplate, map[string]string{"ProjectName": projectName}); err != nil {
+			return err
+		}
+	}
+
+	appServerPath := "cmd/app_server.go"
+	content, err := os.ReadFile(appServerPath)
+	if err != nil {
+		return err
+	}
+
+	appServerStr := string(content)
+	if strings.Contains(appServerStr, "NewRedisSessionStorage") || !strings.Contains(appServerStr, sessionStoragePattern) {
+		return nil
+	}
+
+	return writeGoFile(appServerPath, patchAppServerForRedisSession(appServerStr))
+}
+
+// middlewareAnchor returns the first registration found in appServerStr, so integrations added
+// later still run in the order authentication, sessions, rate limiting, idempotency
+func middlewareAnchor(appServerStr string, patterns ...string) string {
+	for _, pattern := range patterns {
+		if strings.Contains(appServerStr, pattern) {
+			return pattern
+		}
+	}
+	return "\tapp.Use(middleware.AuthMiddleware())\n"
+}
@@ -0,0 +1,593 @@
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package commands
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/templates"
+	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/utils"
+	"github.com/spf13/cobra"
+)
+
+// integrateTracingCmd creates the 'integrate tracing' subcommand for adding OpenTelemetry tracing
+func integrateTracingCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "tracing",
+		Short: "Integrate OpenTelemetry tracing",
+		Long: `Add OpenTelemetry tracing to your Oakhouse project.
+
+This sets up a tracer provider (OTLP or stdout exporter), Fiber middleware creating
+server spans, the GORM OpenTelemetry plugin on the database connection, go-redis
+instrumentation when Redis is integrated, and spans in newly generated services.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := integrateTracing(); err != nil {
+				fmt.Printf("❌ Error integrating tracing: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println("✅ Tracing integration completed successfully!")
+		},
+	}
+}
+
+// integrateTracing adds OpenTelemetry tracing to the current project
+func integrateTracing() error {
+	// Check if we're in an Oakhouse project
+	if !isOakhouseProject() {
+		return fmt.Errorf("not in an Oakhouse project directory. Please run this command from your project root")
+	}
+
+	fmt.Println("🚀 Integrating OpenTelemetry tracing...")
+
+	// 1. Update go.mod with OpenTelemetry dependencies
+	if err := addTracingDependencies(); err != nil {
+		return fmt.Errorf("failed to add tracing dependencies: %v", err)
+	}
+
+	// 2. Update .env.example with exporter configuration
+	if err := addTracingEnvConfig(); err != nil {
+		return fmt.Errorf("failed to add tracing environment configuration: %v", err)
+	}
+
+	// 3. Update config to include tracing fields
+	if err := updateConfigForTracing(); err != nil {
+		return fmt.Errorf("failed to update config for tracing: %v", err)
+	}
+
+	// 4. Create tracer provider adapter and span helpers
+	if err := createTracingFiles(); err != nil {
+		return fmt.Errorf("failed to create tracing files: %v", err)
+	}
+
+	// 5. Initialize the tracer provider in main.go
+	if err := updateMainGoForTracing(); err != nil {
+		return fmt.Errorf("failed to update main.go for tracing: %v", err)
+	}
+
+	// 6. Add server spans to app_server.go
+	if err := updateAppServerForTracing(); err != nil {
+		return fmt.Errorf("failed to update app_server.go for tracing: %v", err)
+	}
+
+	// 7. Enable the GORM OpenTelemetry plugin
+	if err := updateGormForTracing(); err != nil {
+		return fmt.Errorf("failed to update database adapter for tracing: %v", err)
+	}
+
+	// 8. Instrument Redis if it is integrated
+	if utils.FileExists(filepath.Join("adapter", "redis_adapter.go")) {
+		if err := instrumentRedisTracing(); err != nil {
+			return fmt.Errorf("failed to instrument Redis for tracing: %v", err)
+		}
+	}
+
+	// 9. Add spans to the services generated before tracing
+	if err := instrumentExistingServices(); err != nil {
+		return fmt.Errorf("failed to instrument existing services: %v", err)
+	}
+
+	fmt.Println("\n📋 Next steps:")
+	fmt.Println("1. Run 'go mod tidy' to download OpenTelemetry dependencies")
+	fmt.Println("2. Set OTEL_TRACES_EXPORTER=stdout locally or otlp with OTEL_EXPORTER_OTLP_ENDPOINT")
+	fmt.Println("3. Services create a span for each method")
+
+	return nil
+}
+
+// addTracingDependencies adds OpenTelemetry dependencies to go.mod
+func addTracingDependencies() error {
+	fmt.Println("📦 Adding OpenTelemetry dependencies...")
+	return addGoModDependencies(
+		"go.opentelemetry.io/otel v1.24.0",
+		"go.opentelemetry.io/otel/sdk v1.24.0",
+		"go.opentelemetry.io/otel/trace v1.24.0",
+		"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp v1.24.0",
+		"go.opentelemetry.io/otel/exporters/stdout/stdouttrace v1.24.0",
+		"github.com/gofiber/contrib/otelfiber/v2 v2.1.1",
+		"gorm.io/plugin/opentelemetry v0.1.8",
+	)
+}
+
+// addTracingEnvConfig adds tracing configuration to .env.example
+func addTracingEnvConfig() error {
+	fmt.Println("⚙️ Adding tracing environment configuration...")
+
+	tracingConfig := `
+# Tracing Configuration (exporter: otlp, stdout or none)
+OTEL_SERVICE_NAME=
+OTEL_TRACES_EXPORTER=stdout
+OTEL_EXPORTER_OTLP_ENDPOINT=http://localhost:4318
+`
+
+	return appendEnvConfig("OTEL_TRACES_EXPORTER", tracingConfig)
+}
+
+// updateConfigForTracing registers the tracing settings section in the config package
+func updateConfigForTracing() error {
+	fmt.Println("🔧 Updating config for tracing...")
+
+	projectName, err := getProjectName()
+	if err != nil {
+		return err
+	}
+
+	return writeConfigSection("tracing_config.go", templates.TracingConfigTemplate, map[string]string{
+		"ServiceName": filepath.Base(projectName),
+	})
+}
+
+// createTracingFiles creates the tracer provider adapter and the span helpers
+func createTracingFiles() error {
+	fmt.Println("🔧 Creating tracing adapter and utilities...")
+
+	projectName, err := getProjectName()
+	if err != nil {
+		return err
+	}
+
+	files := map[string]string{
+		filepath.Join("adapter", "tracing_adapter.go"): templates.TracingAdapterTemplate,
+		filepath.Join("util", "tracing.go"):            templates.TracingUtilTemplate,
+	}
+
+	for path, tmpl := range files {
+		if utils.FileExists(path) {
+			fmt.Printf("✓ %s already exists\n", path)
+			continue
+		}
+		if err := utils.WriteFile(path, tmpl, map[string]string{"ProjectName": projectName}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateMainGoForTracing initializes the tracer provider before the database connects
+func updateMainGoForTracing() error {
+	mainPath := "cmd/main.go"
+	content, err := os.ReadFile(mainPath)
+	if err != nil {
+		return fmt.Errorf("main.go not found at %s", mainPath)
+	}
+
+	mainStr := string(content)
+
+	// Check if tracing is already initialized
+	if strings.Contains(mainStr, "InitializeTracing") {
+		fmt.Println("✓ main.go already initializes tracing")
+		return nil
+	}
+
+	dbInitPattern := `	// Initialize database
+`
+	if !strings.Contains(mainStr, dbInitPattern) {
+		return fmt.Errorf("could not find database initialization in %s", mainPath)
+	}
+
+	tracingInit := `	// Initialize tracing
+	shutdownTracing, err := adapter.InitializeTracing(cfg)
+	if err != nil {
+		log.Fatal("Failed to initialize tracing:", err)
+	}
+	defer shutdownTracing(context.Background())
+
+` + dbInitPattern
+
+	mainStr = strings.Replace(mainStr, dbInitPattern, tracingInit, 1)
+	mainStr = addImport(mainStr, "context")
+
+	return writeGoFile(mainPath, mainStr)
+}
+
+// updateAppServerForTracing registers the otelfiber middleware ahead of the request logger
+func updateAppServerForTracing() error {
+	appServerPath := "cmd/app_server.go"
+	content, err := os.ReadFile(appServerPath)
+	if err != nil {
+		return fmt.Errorf("app_server.go not found at %s", appServerPath)
+	}
+
+	appServerStr := string(content)
+
+	// Check if tracing middleware is already registered
+	if strings.Contains(appServerStr, "otelfiber") {
+		fmt.Println("✓ app_server.go already contains tracing middleware")
+		return nil
+	}
+
+	appServerStr, err = patchAppServerForTracing(appServerStr)
+	if err != nil {
+		return fmt.Errorf("%v in %s", err, appServerPath)
+	}
+
+	return writeGoFile(appServerPath, appServerStr)
+}
+
+// patchAppServerForTracing registers the otelfiber middleware ahead of the request logger
+func patchAppServerForTracing(appServerStr string) (string, error) {
+	loggerPattern := `	app.Use(logger.New())`
+	if !strings.Contains(appServerStr, loggerPattern) {
+		return "", fmt.Errorf("could not find middleware registration")
+	}
+
+	appServerStr = strings.Replace(appServerStr, loggerPattern, `	app.Use(otelfiber.Middleware())
+`+loggerPattern, 1)
+	return addImport(appServerStr, "github.com/gofiber/contrib/otelfiber/v2"), nil
+}
+
+// updateGormForTracing enables the GORM OpenTelemetry plugin in NewGormDB
+func updateGormForTracing() error {
+	gormPath := filepath.Join("adapter", "postgres", "gorm.go")
+	content, err := os.ReadFile(gormPath)
+	if err != nil {
+		return fmt.Errorf("database adapter not found at %s", gormPath)
+	}
+
+	gormStr := string(content)
+
+	// Check if the plugin is already enabled
+	if strings.Contains(gormStr, "tracing.NewPlugin") {
+		fmt.Println("✓ database adapter already uses the tracing plugin")
+		return nil
+	}
+
+	connectedPattern := `	log.Println("✅ Database connected successfully")`
+	if !strings.Contains(gormStr, connectedPattern) {
+		return fmt.Errorf("could not find database connection setup in %s", gormPath)
+	}
+
+	gormStr = strings.Replace(gormStr, connectedPattern, `	if err := db.Use(tracing.NewPlugin()); err != nil {
+		return nil, fmt.Errorf("failed to enable database tracing: %w", err)
+	}
+
+`+connectedPattern, 1)
+	gormStr = addImport(gormStr, "gorm.io/plugin/opentelemetry/tracing")
+
+	return writeGoFile(gormPath, gormStr)
+}
+
+// instrumentRedisTracing adds go-redis OpenTelemetry instrumentation to the Redis adapter
+func instrumentRedisTracing() error {
+	redisPath := filepath.Join("adapter", "redis_adapter.go")
+	content, err := os.ReadFile(redisPath)
+	if err != nil {
+		return err
+	}
+
+	redisStr := string(content)
+
+	// Check if Redis is already instrumented
+	if strings.Contains(redisStr, "redisotel") {
+		fmt.Println("✓ Redis adapter already instrumented for tracing")
+		return nil
+	}
+
+	redisStr, err = patchRedisAdapterForTracing(redisStr)
+	if err != nil {
+		return fmt.Errorf("%v in %s", err, redisPath)
+	}
+
+	if err := addGoModDependencies(redisotelDependency); err != nil {
+		return err
+	}
+
+	return writeGoFile(redisPath, redisStr)
+}
+
+// redisotelDependency is the go-redis OpenTelemetry instrumentation module
+const redisotelDependency = "github.com/redis/go-redis/extra/redisotel/v9 v9.0.5"
+
+// patchRedisAdapterForTracing instruments the client created by NewRedisAdapter
+func patchRedisAdapterForTracing(redisStr string) (string, error) {
+	pingPattern := `	// Test connection`
+	if !strings.Contains(redisStr, pingPattern) {
+		return "", fmt.Errorf("could not find Redis client setup")
+	}
+
+	redisStr = strings.Replace(redisStr, pingPattern, `	// Instrument Redis commands with OpenTelemetry tracing
+	if err := redisotel.InstrumentTracing(rdb); err != nil {
+		return nil, fmt.Errorf("failed to instrument Redis tracing: %w", err)
+	}
+
+`+pingPattern, 1)
+	return addImport(redisStr, "github.com/redis/go-redis/extra/redisotel/v9"), nil
+}
+
+// serviceConstructorPattern finds the model name of a generated service from its constructor
+var serviceConstructorPattern = regexp.MustCompile(`func New(\w+)Service\(`)
+
+// instrumentExistingServices adds a span to every method of the services generated before tracing
+// was integrated, and names the methods it had to leave alone
+func instrumentExistingServices() error {
+	fmt.Println("🔧 Instrumenting existing services...")
+
+	projectName, err := getProjectName()
+	if err != nil {
+		return err
+	}
+
+	paths, err := filepath.Glob(filepath.Join("service", "*_service.go"))
+	if err != nil {
+		return err
+	}
+
+	var skipped []string
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		src, instrumented, notInstrumented, err := instrumentServiceSource(string(content))
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s (%v)", path, err))
+			continue
+		}
+		for _, method := range notInstrumented {
+			skipped = append(skipped, path+" "+method)
+		}
+		if instrumented == 0 {
+			continue
+		}
+
+		// Only the inserted lines change, so customized services keep their formatting
+		if err := os.WriteFile(path, []byte(addImport(src, projectName+"/util")), 0644); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Instrumented %d methods in %s\n", instrumented, path)
+	}
+
+	if len(skipped) > 0 {
+		fmt.Println("⚠️ These service methods were not instrumented; add util.StartSpan and util.EndSpan by hand:")
+		for _, method := range skipped {
+			fmt.Printf("   - %s\n", method)
+		}
+	}
+	return nil
+}
+
+// instrumentServiceSource starts a span in each exported context-taking service method that returns
+// an error and has none yet. The error result is named so a deferred util.EndSpan records every
+// error the method returns. Methods that already declare err or span themselves are reported
+// rather than changed.
+func instrumentServiceSource(src string) (string, int, []string, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, 0)
+	if err != nil {
+		return "", 0, nil, err
+	}
+
+	serviceName := ""
+	if match := serviceConstructorPattern.FindStringSubmatch(src); match != nil {
+		serviceName = match[1] + "Service"
+	}
+
+	type edit struct {
+		start, end int
+		text       string
+	}
+	var edits []edit
+	var skipped []string
+	instrumented := 0
+
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || fn.Body == nil || !fn.Name.IsExported() || !takesContext(fn) || !returnsError(fn) {
+			continue
+		}
+		receiver := receiverTypeName(fn)
+		if !strings.HasSuffix(receiver, "Service") {
+			continue
+		}
+		body := src[fset.Position(fn.Body.Lbrace).Offset:fset.Position(fn.Body.Rbrace).Offset]
+		if strings.Contains(body, "StartSpan(") {
+			continue
+		}
+
+		results := fn.Type.Results.List
+		errName := "err"
+		if last := results[len(results)-1]; len(last.Names) > 0 && last.Names[len(last.Names)-1].Name != "_" {
+			errName = last.Names[len(last.Names)-1].Name
+		} else if len(last.Names) > 0 || clashesWithResult(fn, "err") {
+			skipped = append(skipped, fn.Name.Name)
+			continue
+		}
+		if declaresName(fn, "span") {
+			skipped = append(skipped, fn.Name.Name)
+			continue
+		}
+
+		// Name the results, keeping their types as written
+		if len(results[0].Names) == 0 {
+			types := make([]string, len(results))
+			for i, field := range results {
+				name := "_"
+				if i == len(results)-1 {
+					name = errName
+				}
+				types[i] = name + " " + src[fset.Position(field.Type.Pos()).Offset:fset.Position(field.Type.End()).Offset]
+			}
+			start := fset.Position(results[0].Type.Pos()).Offset
+			end := fset.Position(results[len(results)-1].Type.End()).Offset
+			if fn.Type.Results.Opening.IsValid() {
+				start = fset.Position(fn.Type.Results.Opening).Offset
+				end = fset.Position(fn.Type.Results.Closing).Offset + 1
+			}
+			edits = append(edits, edit{start, end, "(" + strings.Join(types, ", ") + ")"})
+		}
+
+		name := serviceName
+		if name == "" {
+			name = strings.ToUpper(receiver[:1]) + receiver[1:]
+		}
+		lbrace := fset.Position(fn.Body.Lbrace).Offset + 1
+		edits = append(edits, edit{lbrace, lbrace, fmt.Sprintf("\n\tctx, span := util.StartSpan(ctx, %q)\n\tdefer util.EndSpan(span, &%s)\n", name+"."+fn.Name.Name, errName)})
+		instrumented++
+	}
+
+	// Apply from the end so earlier offsets stay valid
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start > edits[j].start })
+	for _, e := range edits {
+		src = src[:e.start] + e.text + src[e.end:]
+	}
+	return src, instrumented, skipped, nil
+}
+
+// takesContext reports whether fn's first parameter is ctx context.Context
+func takesContext(fn *ast.FuncDecl) bool {
+	params := fn.Type.Params.List
+	if len(params) == 0 || len(params[0].Names) == 0 || params[0].Names[0].Name != "ctx" {
+		return false
+	}
+	sel, ok := params[0].Type.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && pkg.Name == "context" && sel.Sel.Name == "Context"
+}
+
+// returnsError reports whether fn's last result is an error
+func returnsError(fn *ast.FuncDecl) bool {
+	if fn.Type.Results == nil || len(fn.Type.Results.List) == 0 {
+		return false
+	}
+	ident, ok := fn.Type.Results.List[len(fn.Type.Results.List)-1].Type.(*ast.Ident)
+	return ok && ident.Name == "error"
+}
+
+// receiverTypeName returns the type name of fn's receiver, without the pointer
+func receiverTypeName(fn *ast.FuncDecl) string {
+	expr := fn.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// clashesWithResult reports whether a result named name would not compile in fn: a parameter
+// has that name, or the top scope of the body declares it with var or with a := that introduces
+// no other new variable
+func clashesWithResult(fn *ast.FuncDecl, name string) bool {
+	declared := map[string]bool{name: true}
+	for _, field := range fn.Type.Params.List {
+		for _, ident := range field.Names {
+			if ident.Name == name {
+				return true
+			}
+			declared[ident.Name] = true
+		}
+	}
+
+	for _, stmt := range fn.Body.List {
+		switch s := stmt.(type) {
+		case *ast.AssignStmt:
+			if s.Tok != token.DEFINE {
+				continue
+			}
+			assigns, introduces := false, false
+			for _, lhs := range s.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				if !ok || ident.Name == "_" {
+					continue
+				}
+				if ident.Name == name {
+					assigns = true
+				} else if !declared[ident.Name] {
+					introduces = true
+				}
+				declared[ident.Name] = true
+			}
+			if assigns && !introduces {
+				return true
+			}
+		case *ast.DeclStmt:
+			gen, ok := s.Decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				if value, ok := spec.(*ast.ValueSpec); ok {
+					for _, ident := range value.Names {
+						if ident.Name == name {
+							return true
+						}
+						declared[ident.Name] = true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// declaresName reports whether fn has a parameter named name or declares it anywhere in the top
+// scope of its body
+func declaresName(fn *ast.FuncDecl, name string) bool {
+	for _, field := range fn.Type.Params.List {
+		for _, ident := range field.Names {
+			if ident.Name == name {
+				return true
+			}
+		}
+	}
+	for _, stmt := range fn.Body.List {
+		switch s := stmt.(type) {
+		case *ast.AssignStmt:
+			if s.Tok != token.DEFINE {
+				continue
+			}
+			for _, lhs := range s.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok && ident.Name == name {
+					return true
+				}
+			}
+		case *ast.DeclStmt:
+			gen, ok := s.Decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				if value, ok := spec.(*ast.ValueSpec); ok {
+					for _, ident := range value.Names {
+						if ident.Name == name {
+							return true
+						}
+					}
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,513 @@
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/utils"
+	"github.com/spf13/cobra"
+)
+
+// routeMethods maps Fiber router methods to the HTTP method they register
+var routeMethods = map[string]string{
+	"Get":     "GET",
+	"Head":    "HEAD",
+	"Post":    "POST",
+	"Put":     "PUT",
+	"Patch":   "PATCH",
+	"Delete":  "DELETE",
+	"Connect": "CONNECT",
+	"Options": "OPTIONS",
+	"Trace":   "TRACE",
+	"All":     "ALL",
+}
+
+// routeInfo is a single endpoint found by static analysis
+type routeInfo struct {
+	Method     string   `json:"method"`
+	Path       string   `json:"path"`
+	Handler    string   `json:"handler"`
+	Middleware []string `json:"middleware,omitempty"`
+	Source     string   `json:"source"`
+	static     bool
+}
+
+// routeTable is the result of analyzing the project's route registration
+type routeTable struct {
+	GlobalMiddleware []string    `json:"globalMiddleware"`
+	Routes           []routeInfo `json:"routes"`
+}
+
+// routeScope is the path prefix and middleware a router variable carries
+type routeScope struct {
+	prefix     string
+	middleware []string
+}
+
+// routeAnalyzer follows route registration through SetupRoutes, Setup*Routes and AppServer methods
+type routeAnalyzer struct {
+	fset     *token.FileSet
+	funcs    map[string]*ast.FuncDecl
+	methods  map[string]*ast.FuncDecl
+	visiting map[string]bool
+	table    routeTable
+}
+
+// runtimeRoute is an entry of the /debug/routes endpoint of a running application
+type runtimeRoute struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+}
+
+// RoutesCmd creates the command for listing the project's HTTP endpoints.
+// Statically analyzes route/*.go and cmd/app_server.go without running the application,
+// and can compare the result with the routes a running development server reports.
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+func RoutesCmd() *cobra.Command {
+	var asJSON bool
+	var runtimeURL string
+
+	cmd := &cobra.Command{
+		Use:   "routes",
+		Short: "List all registered HTTP endpoints",
+		Long: `List every endpoint of the current project with its method, path, handler and middleware.
+
+Routes are found by statically analyzing SetupRoutes in route/v1.go, each Setup*Routes
+function it calls and the routes AppServer registers in cmd/app_server.go.
+
+Use --runtime with the base URL of a running development server to compare the
+static table with the routes Fiber actually registered (served on /debug/routes
+outside production).`,
+		Example: `  oakhouse routes
+  oakhouse routes --json
+  oakhouse routes --runtime http://localhost:8080`,
+		Run: func(cmd *cobra.Command, args []string) {
+			table, err := analyzeRoutes()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "❌ Error analyzing routes: %v\n", err)
+				os.Exit(1)
+			}
+
+			if runtimeURL != "" {
+				if err := compareRuntimeRoutes(table, runtimeURL); err != nil {
+					fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
+			if asJSON {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "  ")
+				if err := encoder.Encode(table); err != nil {
+					fmt.Fprintf(os.Stderr, "❌ Error encoding routes: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
+			printRouteTable(table)
+		},
+	}
+
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print the routes as JSON")
+	cmd.Flags().StringVar(&runtimeURL, "runtime", "", "Compare with the routes of a running server (e.g. http://localhost:8080)")
+
+	return cmd
+}
+
+// analyzeRoutes builds the route table of the project in the current directory
+func analyzeRoutes() (*routeTable, error) {
+	if !utils.FileExists(filepath.Join("route", "v1.go")) {
+		return nil, fmt.Errorf("route/v1.go not found. Run 'oakhouse routes' from your project root")
+	}
+
+	analyzer := &routeAnalyzer{
+		fset:     token.NewFileSet(),
+		funcs:    map[string]*ast.FuncDecl{},
+		methods:  map[string]*ast.FuncDecl{},
+		visiting: map[string]bool{},
+	}
+
+	routeFiles, _ := filepath.Glob(filepath.Join("route", "*.go"))
+	for _, file := range routeFiles {
+		parsed, err := parser.ParseFile(analyzer.fset, file, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range parsed.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil {
+				analyzer.funcs[fn.Name.Name] = fn
+			}
+		}
+	}
+
+	var start *ast.FuncDecl
+	if appServer, err := parser.ParseFile(analyzer.fset, filepath.Join("cmd", "app_server.go"), nil, 0); err == nil {
+		for _, decl := range appServer.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			switch {
+			case fn.Recv != nil:
+				analyzer.methods[fn.Name.Name] = fn
+			case fn.Name.Name == "NewAppServer":
+				analyzer.table.GlobalMiddleware = analyzer.globalMiddleware(fn)
+			}
+		}
+		start = analyzer.methods["Start"]
+	}
+
+	if start != nil && len(start.Recv.List[0].Names) > 0 {
+		// Routes registered by AppServer itself (health probes) and through route.SetupRoutes
+		receiver := start.Recv.List[0].Names[0].Name
+		analyzer.walk(start.Body.List, map[string]routeScope{receiver + ".app": {}}, map[string]string{})
+	} else if setup, ok := analyzer.funcs["SetupRoutes"]; ok {
+		analyzer.call(setup, []*routeScope{{}})
+	} else {
+		return nil, fmt.Errorf("could not find SetupRoutes in route/v1.go")
+	}
+
+	if analyzer.table.GlobalMiddleware == nil {
+		analyzer.table.GlobalMiddleware = []string{}
+	}
+	if analyzer.table.Routes == nil {
+		analyzer.table.Routes = []routeInfo{}
+	}
+	return &analyzer.table, nil
+}
+
+// globalMiddleware lists the middleware NewAppServer registers with app.Use
+func (a *routeAnalyzer) globalMiddleware(fn *ast.FuncDecl) []string {
+	var middleware []string
+	ast.Inspect(fn.Body, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Use" && a.render(sel.X) == "app" {
+			for _, arg := range call.Args {
+				middleware = append(middleware, a.handlerName(arg, nil))
+			}
+		}
+		return true
+	})
+	return middleware
+}
+
+// call analyzes a route function with the scopes of the router arguments it receives;
+// a nil entry marks an argument that is not a router
+func (a *routeAnalyzer) call(fn *ast.FuncDecl, args []*routeScope) {
+	if a.visiting[fn.Name.Name] || fn.Body == nil {
+		return
+	}
+	a.visiting[fn.Name.Name] = true
+	defer delete(a.visiting, fn.Name.Name)
+
+	scopes := map[string]routeScope{}
+	i := 0
+	for _, param := range fn.Type.Params.List {
+		for _, name := range param.Names {
+			if i < len(args) && args[i] != nil {
+				scopes[name.Name] = *args[i]
+			}
+			i++
+		}
+	}
+
+	a.walk(fn.Body.List, scopes, map[string]string{})
+}
+
+// walk follows the statements of a function body, tracking router variables and constructors
+func (a *routeAnalyzer) walk(stmts []ast.Stmt, scopes map[string]routeScope, constructors map[string]string) {
+	for _, stmt := range stmts {
+		switch s := stmt.(type) {
+		case *ast.AssignStmt:
+			for i, rhs := range s.Rhs {
+				if i >= len(s.Lhs) {
+					break
+				}
+				name := a.render(s.Lhs[i])
+				call, ok := rhs.(*ast.CallExpr)
+				if !ok {
+					continue
+				}
+				if scope, ok := a.groupScope(call, scopes); ok {
+					scopes[name] = scope
+					continue
+				}
+				// Remember handler.NewXHandler(...) so routes show XHandler.Method
+				if sel, ok := call.Fun.(*ast.SelectorExpr); ok && strings.HasPrefix(sel.Sel.Name, "New") {
+					constructors[name] = strings.TrimPrefix(sel.Sel.Name, "New")
+				}
+			}
+		case *ast.ExprStmt:
+			if call, ok := s.X.(*ast.CallExpr); ok {
+				a.registerCall(call, scopes, constructors)
+			}
+		case *ast.IfStmt:
+			a.walk(s.Body.List, scopes, constructors)
+			if block, ok := s.Else.(*ast.BlockStmt); ok {
+				a.walk(block.List, scopes, constructors)
+			}
+		case *ast.BlockStmt:
+			a.walk(s.List, scopes, constructors)
+		}
+	}
+}
+
+// groupScope returns the scope of router.Group(prefix, middleware...) calls
+func (a *routeAnalyzer) groupScope(call *ast.CallExpr, scopes map[string]routeScope) (routeScope, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Group" {
+		return routeScope{}, false
+	}
+	parent, ok := scopes[a.render(sel.X)]
+	if !ok || len(call.Args) == 0 {
+		return routeScope{}, false
+	}
+
+	scope := routeScope{
+		prefix:     joinRoutePath(parent.prefix, a.pathArg(call.Args[0])),
+		middleware: append([]string{}, parent.middleware...),
+	}
+	for _, arg := range call.Args[1:] {
+		scope.middleware = append(scope.middleware, a.handlerName(arg, nil))
+	}
+	return scope, true
+}
+
+// registerCall records routes, router middleware and calls into other route functions
+func (a *routeAnalyzer) registerCall(call *ast.CallExpr, scopes map[string]routeScope, constructors map[string]string) {
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		// SetupXRoutes(api, db) within the route package
+		if fn, ok := a.funcs[fun.Name]; ok {
+			a.call(fn, a.argScopes(call.Args, scopes))
+		}
+		return
+	case *ast.SelectorExpr:
+		receiver := a.render(fun.X)
+
+		// route.SetupRoutes(s.app, s.db) from cmd/app_server.go
+		if receiver == "route" {
+			if fn, ok := a.funcs[fun.Sel.Name]; ok {
+				a.call(fn, a.argScopes(call.Args, scopes))
+			}
+			return
+		}
+
+		// s.setupHealthRoutes() and other AppServer methods
+		if fn, ok := a.methods[fun.Sel.Name]; ok && !strings.Contains(receiver, ".") {
+			if _, isRouter := scopes[receiver]; !isRouter && !a.visiting["."+fun.Sel.Name] {
+				a.visiting["."+fun.Sel.Name] = true
+				a.walk(fn.Body.List, scopes, map[string]string{})
+				delete(a.visiting, "."+fun.Sel.Name)
+				return
+			}
+		}
+
+		scope, ok := scopes[receiver]
+		if !ok {
+			return
+		}
+
+		switch method := fun.Sel.Name; {
+		case method == "Use":
+			var middleware []string
+			for _, arg := range call.Args {
+				if _, isPath := arg.(*ast.BasicLit); !isPath {
+					middleware = append(middleware, a.handlerName(arg, nil))
+				}
+			}
+			scope.middleware = append(append([]string{}, scope.middleware...), middleware...)
+			scopes[receiver] = scope
+		case method == "Static" && len(call.Args) >= 2:
+			a.addRoute(call, "GET", joinRoutePath(scope.prefix, a.pathArg(call.Args[0])), "static "+a.pathArg(call.Args[1]), scope.middleware, true)
+		case routeMethods[method] != "" && len(call.Args) >= 2:
+			middleware := append([]string{}, scope.middleware...)
+			for _, arg := range call.Args[1 : len(call.Args)-1] {
+				middleware = append(middleware, a.handlerName(arg, constructors))
+			}
+			handler := a.handlerName(call.Args[len(call.Args)-1], constructors)
+			a.addRoute(call, routeMethods[method], joinRoutePath(scope.prefix, a.pathArg(call.Args[0])), handler, middleware, false)
+		}
+	}
+}
+
+// argScopes maps call arguments to the scopes of the routers they refer to
+func (a *routeAnalyzer) argScopes(args []ast.Expr, scopes map[string]routeScope) []*routeScope {
+	result := make([]*routeScope, len(args))
+	for i, arg := range args {
+		if scope, ok := scopes[a.render(arg)]; ok {
+			result[i] = &scope
+		}
+	}
+	return result
+}
+
+// addRoute appends a route with its source position
+func (a *routeAnalyzer) addRoute(call *ast.CallExpr, method, path, handler string, middleware []string, static bool) {
+	position := a.fset.Position(call.Pos())
+	a.table.Routes = append(a.table.Routes, routeInfo{
+		Method:     method,
+		Path:       path,
+		Handler:    handler,
+		Middleware: middleware,
+		Source:     fmt.Sprintf("%s:%d", filepath.ToSlash(position.Filename), position.Line),
+		static:     static,
+	})
+}
+
+// handlerName renders a handler or middleware expression for display
+func (a *routeAnalyzer) handlerName(expr ast.Expr, constructors map[string]string) string {
+	switch e := expr.(type) {
+	case *ast.FuncLit:
+		return "inline func"
+	case *ast.CallExpr:
+		return a.render(e.Fun) + "()"
+	case *ast.SelectorExpr:
+		if typeName, ok := constructors[a.render(e.X)]; ok {
+			return typeName + "." + e.Sel.Name
+		}
+	}
+	return a.render(expr)
+}
+
+// pathArg returns the value of a string literal path, or the expression in braces
+func (a *routeAnalyzer) pathArg(expr ast.Expr) string {
+	if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+		if value, err := strconv.Unquote(lit.Value); err == nil {
+			return value
+		}
+	}
+	return "{" + a.render(expr) + "}"
+}
+
+// render prints an expression as Go source
+func (a *routeAnalyzer) render(expr ast.Expr) string {
+	var out strings.Builder
+	printer.Fprint(&out, a.fset, expr)
+	return out.String()
+}
+
+// joinRoutePath joins a group prefix and a path the way Fiber does
+func joinRoutePath(prefix, path string) string {
+	if path == "" {
+		path = "/"
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return strings.TrimRight(prefix, "/") + path
+}
+
+// normalizeRoutePath drops the trailing slash Fiber ignores when matching
+func normalizeRoutePath(path string) string {
+	if len(path) > 1 {
+		return strings.TrimRight(path, "/")
+	}
+	return path
+}
+
+// printRouteTable prints the routes as an aligned table
+func printRouteTable(table *routeTable) {
+	if len(table.GlobalMiddleware) > 0 {
+		fmt.Printf("Global middleware: %s\n\n", strings.Join(table.GlobalMiddleware, ", "))
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "METHOD\tPATH\tHANDLER\tMIDDLEWARE\tSOURCE")
+	for _, route := range table.Routes {
+		middleware := strings.Join(route.Middleware, ", ")
+		if middleware == "" {
+			middleware = "-"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", route.Method, route.Path, route.Handler, middleware, route.Source)
+	}
+	writer.Flush()
+
+	fmt.Printf("\n%d route(s)\n", len(table.Routes))
+}
+
+// compareRuntimeRoutes compares the static table with the routes a running server reports
+func compareRuntimeRoutes(table *routeTable, baseURL string) error {
+	endpoint := strings.TrimRight(baseURL, "/") + "/debug/routes"
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get(endpoint)
+	if err != nil {
+		return fmt.Errorf("could not reach %s: %v", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%s not found. The endpoint is only served outside production by projects generated or upgraded with v1.35.0+", endpoint)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s", endpoint, resp.Status)
+	}
+
+	var runtimeRoutes []runtimeRoute
+	if err := json.NewDecoder(resp.Body).Decode(&runtimeRoutes); err != nil {
+		return fmt.Errorf("invalid response from %s: %v", endpoint, err)
+	}
+
+	key := func(method, path string) string {
+		return method + " " + normalizeRoutePath(path)
+	}
+
+	// Static file routes are middleware in Fiber and not reported at runtime
+	staticRoutes := map[string]bool{}
+	for _, route := range table.Routes {
+		if !route.static {
+			staticRoutes[key(route.Method, route.Path)] = true
+		}
+	}
+	liveRoutes := map[string]bool{}
+	for _, route := range runtimeRoutes {
+		liveRoutes[key(route.Method, route.Path)] = true
+	}
+
+	var onlyStatic, onlyRuntime []string
+	for route := range staticRoutes {
+		if !liveRoutes[route] {
+			onlyStatic = append(onlyStatic, route)
+		}
+	}
+	for route := range liveRoutes {
+		if !staticRoutes[route] {
+			onlyRuntime = append(onlyRuntime, route)
+		}
+	}
+
+	fmt.Printf("🔍 Compared %d static route(s) with %d runtime route(s) from %s\n", len(staticRoutes), len(liveRoutes), endpoint)
+	if len(onlyStatic) == 0 && len(onlyRuntime) == 0 {
+		fmt.Println("✅ Static analysis matches the running server")
+		return nil
+	}
+
+	sort.Strings(onlyStatic)
+	sort.Strings(onlyRuntime)
+	for _, route := range onlyStatic {
+		fmt.Printf("   - %s (found in source, not registered at runtime)\n", route)
+	}
+	for _, route := range onlyRuntime {
+		fmt.Printf("   + %s (registered at runtime, not found in source)\n", route)
+	}
+	return nil
+}
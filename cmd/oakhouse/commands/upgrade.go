@@ -0,0 +1,595 @@
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/templates"
+	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/utils"
+	"github.com/spf13/cobra"
+)
+
+// upgradeMigration is a codemod that brings projects generated before version up to date
+type upgradeMigration struct {
+	version     string
+	name        string
+	description string
+	apply       func(plan *upgradePlan) error
+}
+
+// upgradeMigrations lists every codemod in the order they must run.
+// Each one inspects the project and leaves files that are already up to date untouched.
+var upgradeMigrations = []upgradeMigration{
+	{
+		version:     "1.35.0",
+		name:        "typed-config",
+		description: "Bind Config from struct tags, add configuration profiles and move integration settings into sections",
+		apply:       migrateTypedConfig,
+	},
+	{
+		version:     "1.35.0",
+		name:        "app-server-lifecycle",
+		description: "Regenerate AppServer with graceful shutdown, lifecycle hooks and health probes",
+		apply:       migrateAppServerLifecycle,
+	},
+	{
+		version:     "1.35.0",
+		name:        "health-routes",
+		description: "Serve /health from AppServer and skip authentication for every /health path",
+		apply:       migrateHealthRoutes,
+	},
+	{
+		version:     "1.35.0",
+		name:        "handler-user-context",
+		description: "Pass ctx.UserContext() from handlers to services",
+		apply:       migrateHandlerUserContext,
+	},
+	{
+		version:     "1.35.0",
+		name:        "cors-patch-method",
+		description: "Allow PATCH requests through CORS for the generated PATCH endpoints",
+		apply:       migrateCorsPatchMethod,
+	},
+	{
+		version:     "1.35.0",
+		name:        "cache-manager-scan",
+		description: "Namespace CacheManager keys and replace KEYS and FLUSHDB with SCAN and UNLINK",
+		apply:       migrateCacheManagerScan,
+	},
+	{
+		version:     "1.35.0",
+		name:        "dependency-versions",
+		description: "Update go.mod requirements to the versions used by new projects",
+		apply:       migrateDependencyVersions,
+	},
+}
+
+// upgradePlan collects file changes in memory so they can be previewed before anything is written
+type upgradePlan struct {
+	projectName string
+	changes     map[string]string
+	originals   map[string]string
+	order       []string
+	notes       []string
+}
+
+// read returns the pending content of a file, falling back to the file on disk
+func (p *upgradePlan) read(path string) (string, bool) {
+	if content, ok := p.changes[path]; ok {
+		return content, true
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	return string(content), true
+}
+
+// write records new content for a file. Content is kept as given rather than gofmt-ed,
+// so the diff only shows the regions a codemod patched.
+func (p *upgradePlan) write(path, content string) {
+	if _, seen := p.originals[path]; !seen {
+		original, _ := os.ReadFile(path)
+		p.originals[path] = string(original)
+		p.order = append(p.order, path)
+	}
+	p.changes[path] = content
+}
+
+// create renders a project template into a file that does not exist yet
+func (p *upgradePlan) create(path, tmpl string, data interface{}) error {
+	if _, exists := p.read(path); exists {
+		return nil
+	}
+
+	content, err := utils.RenderTemplate(tmpl, data)
+	if err != nil {
+		return fmt.Errorf("failed to render %s: %w", path, err)
+	}
+	p.write(path, content)
+	return nil
+}
+
+// note records a message shown after the migrations run
+func (p *upgradePlan) note(format string, args ...interface{}) {
+	p.notes = append(p.notes, fmt.Sprintf(format, args...))
+}
+
+// templateData is the data project templates are rendered with
+func (p *upgradePlan) templateData() map[string]string {
+	return map[string]string{
+		"ProjectName": p.projectName,
+		"ModuleName":  strings.ReplaceAll(p.projectName, "-", ""),
+		"Version":     utils.Version,
+	}
+}
+
+// UpgradeCmd creates the command for migrating a project to the current CLI version.
+// Applies the versioned codemods added since the project was generated or last upgraded,
+// shows a diff of every change and records the new version in .oakhouse.json.
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+func UpgradeCmd() *cobra.Command {
+	var dryRun bool
+	var from string
+
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Migrate the current project to this CLI version",
+		Long: `Upgrade a project generated with an older Oakhouse CLI.
+
+The project version is read from .oakhouse.json (or the version badge in
+static/index.html for projects created before it existed). Every codemod added
+after that version is applied, a diff of the changes is shown and the new
+version is recorded in .oakhouse.json.
+
+Use --dry-run to preview the diff without writing any file.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runUpgrade(dryRun, from); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ Error upgrading project: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the changes without writing any file")
+	cmd.Flags().StringVar(&from, "from", "", "Version the project was generated with, when it cannot be detected")
+
+	return cmd
+}
+
+// runUpgrade applies pending migrations to the project in the current directory
+func runUpgrade(dryRun bool, from string) error {
+	if !isOakhouseProject() {
+		return fmt.Errorf("not in an Oakhouse project directory. Please run this command from your project root")
+	}
+
+	projectName, err := getProjectName()
+	if err != nil {
+		return err
+	}
+
+	projectVersion := from
+	if projectVersion == "" {
+		projectVersion = projectGeneratorVersion()
+	}
+	if projectVersion == "" {
+		return fmt.Errorf("could not determine the version this project was generated with. Pass it with --from (e.g. --from 1.34.0)")
+	}
+
+	if utils.CompareVersions(projectVersion, utils.Version) >= 0 {
+		fmt.Printf("✅ Project is already up to date (v%s)\n", projectVersion)
+		if !dryRun && !utils.FileExists(utils.ManifestFile) {
+			return utils.WriteProjectManifest(&utils.ProjectManifest{Version: projectVersion})
+		}
+		return nil
+	}
+
+	fmt.Printf("🚀 Upgrading project from v%s to v%s...\n\n", projectVersion, utils.Version)
+
+	plan := &upgradePlan{
+		projectName: projectName,
+		changes:     map[string]string{},
+		originals:   map[string]string{},
+	}
+
+	for _, migration := range upgradeMigrations {
+		if utils.CompareVersions(migration.version, projectVersion) <= 0 || utils.CompareVersions(migration.version, utils.Version) > 0 {
+			continue
+		}
+
+		fmt.Printf("🔧 [v%s] %s: %s\n", migration.version, migration.name, migration.description)
+		if err := migration.apply(plan); err != nil {
+			return fmt.Errorf("migration %s failed, no files were changed: %w", migration.name, err)
+		}
+	}
+
+	changed := 0
+	for _, path := range plan.order {
+		if diff := utils.LineDiff(path, plan.originals[path], plan.changes[path]); diff != "" {
+			fmt.Printf("\n%s", diff)
+			changed++
+		}
+	}
+
+	if len(plan.notes) > 0 {
+		fmt.Println("\n📝 Notes:")
+		for _, note := range plan.notes {
+			fmt.Printf("   - %s\n", note)
+		}
+	}
+
+	if dryRun {
+		fmt.Printf("\n🔍 Dry run - %d file(s) would change, nothing was written\n", changed)
+		return nil
+	}
+
+	for _, path := range plan.order {
+		if plan.changes[path] == plan.originals[path] {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(plan.changes[path]), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	if err := utils.WriteProjectManifest(&utils.ProjectManifest{Version: utils.Version, Notes: plan.notes}); err != nil {
+		return fmt.Errorf("failed to record project version: %w", err)
+	}
+
+	fmt.Printf("\n✅ Upgraded to v%s, %d file(s) changed\n", utils.Version, changed)
+	fmt.Println("\n📋 Next steps:")
+	fmt.Println("1. Run 'go mod tidy' to download updated dependencies")
+	fmt.Println("2. Run 'go build ./...' and your tests")
+	fmt.Println("3. Review the changes with 'git diff' before committing")
+
+	return nil
+}
+
+// migrateTypedConfig replaces the getEnv based Config with the struct tag loader.
+// Settings the project added to Config are kept; Redis and tracing settings move into sections.
+func migrateTypedConfig(plan *upgradePlan) error {
+	configPath := filepath.Join("config", "env_config.go")
+	configStr, ok := plan.read(configPath)
+	if !ok {
+		return fmt.Errorf("%s not found", configPath)
+	}
+	if !strings.Contains(configStr, "func getEnv(") {
+		return nil
+	}
+
+	// Settings declared by the template or moved into integration sections
+	knownKeys := map[string]bool{}
+	for _, key := range []string{
+		"DB_HOST", "DB_PORT", "DB_USER", "DB_PASSWORD", "DB_NAME", "DB_SSL_MODE", "PORT", "ENV", "JWT_SECRET",
+		"SHUTDOWN_TIMEOUT", "SHUTDOWN_DRAIN_DELAY", "HEALTH_CHECK_TIMEOUT", "HEALTH_CACHE_TTL",
+		"REDIS_URL", "REDIS_PASSWORD", "REDIS_DB",
+		"OTEL_SERVICE_NAME", "OTEL_TRACES_EXPORTER", "OTEL_EXPORTER_OTLP_ENDPOINT",
+	} {
+		knownKeys[key] = true
+	}
+
+	getEnvLine := regexp.MustCompile(`(\w+):\s*getEnv\("([A-Za-z0-9_]+)",\s*"((?:[^"\\]|\\.)*)"\)`)
+	var customMatches [][]string
+	nameWidth := 0
+	for _, match := range getEnvLine.FindAllStringSubmatch(configStr, -1) {
+		if knownKeys[match[2]] {
+			continue
+		}
+		customMatches = append(customMatches, match)
+		if len(match[1]) > nameWidth {
+			nameWidth = len(match[1])
+		}
+	}
+
+	// Fields are aligned the way gofmt would, since the file is not reformatted
+	var customFields []string
+	for _, match := range customMatches {
+		tag := fmt.Sprintf(`env:"%s"`, match[2])
+		if match[3] != "" {
+			tag += fmt.Sprintf(` default:"%s"`, match[3])
+		}
+		customFields = append(customFields, fmt.Sprintf("\t%-*s string `%s`", nameWidth, match[1], tag))
+	}
+
+	newConfig, err := utils.RenderTemplate(templates.EnvConfigTemplate, plan.templateData())
+	if err != nil {
+		return err
+	}
+	if len(customFields) > 0 {
+		structStart := strings.Index(newConfig, "type Config struct {")
+		structEnd := structStart + strings.Index(newConfig[structStart:], "\n}\n")
+		newConfig = newConfig[:structEnd] + "\n\n\t// Settings migrated from getEnv\n" + strings.Join(customFields, "\n") + newConfig[structEnd:]
+		plan.note("Kept %d custom setting(s) in config/env_config.go as strings; change their types if needed", len(customFields))
+	}
+	plan.write(configPath, newConfig)
+
+	for path, tmpl := range map[string]string{
+		filepath.Join("config", "loader.go"):        templates.ConfigLoaderTemplate,
+		filepath.Join("config", "development.yaml"): templates.DevelopmentProfileTemplate,
+		filepath.Join("config", "production.yaml"):  templates.ProductionProfileTemplate,
+	} {
+		if err := plan.create(path, tmpl, plan.templateData()); err != nil {
+			return err
+		}
+	}
+
+	// Integration settings become sections
+	if strings.Contains(configStr, "RedisURL") {
+		if err := plan.create(filepath.Join("config", "redis_config.go"), templates.RedisConfigTemplate, nil); err != nil {
+			return err
+		}
+	}
+	if strings.Contains(configStr, "TracingExporter") {
+		if err := plan.create(filepath.Join("config", "tracing_config.go"), templates.TracingConfigTemplate, map[string]string{
+			"ServiceName": filepath.Base(plan.projectName),
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := migrateMainForTypedConfig(plan); err != nil {
+		return err
+	}
+
+	// Adapters reading the removed Config fields are regenerated
+	redisPath := filepath.Join("adapter", "redis_adapter.go")
+	if redisStr, ok := plan.read(redisPath); ok && strings.Contains(redisStr, "cfg.RedisURL") {
+		newRedis, err := utils.RenderTemplate(fmt.Sprintf(templates.RedisAdapterTemplate, plan.projectName), nil)
+		if err != nil {
+			return err
+		}
+		if strings.Contains(redisStr, "redisotel") {
+			if newRedis, err = patchRedisAdapterForTracing(newRedis); err != nil {
+				return err
+			}
+		}
+		plan.write(redisPath, newRedis)
+		plan.note("%s was regenerated to read cfg.Redis(); re-apply any custom methods shown as removed in the diff", redisPath)
+	}
+
+	tracingPath := filepath.Join("adapter", "tracing_adapter.go")
+	if tracingStr, ok := plan.read(tracingPath); ok && strings.Contains(tracingStr, "cfg.TracingExporter") {
+		newTracing, err := utils.RenderTemplate(templates.TracingAdapterTemplate, plan.templateData())
+		if err != nil {
+			return err
+		}
+		plan.write(tracingPath, newTracing)
+	}
+
+	// Profile files ship with the binary instead of .env.example
+	envCopy := "COPY --from=builder /app/.env.example .env\n"
+	if dockerfile, ok := plan.read("Dockerfile"); ok && strings.Contains(dockerfile, envCopy) {
+		plan.write("Dockerfile", strings.Replace(dockerfile, envCopy, "COPY --from=builder /app/config/*.yaml ./config/\n", 1))
+		plan.note("Dockerfile no longer copies .env.example into the image as .env; it ships config/*.yaml instead, so set secrets such as DB_PASSWORD and JWT_SECRET in the container environment")
+	}
+
+	goMod, ok := plan.read("go.mod")
+	if !ok {
+		return fmt.Errorf("go.mod not found")
+	}
+	goMod, err = addGoModRequires(goMod, "gopkg.in/yaml.v3 v3.0.1")
+	if err != nil {
+		return err
+	}
+	plan.write("go.mod", goMod)
+
+	return nil
+}
+
+// migrateMainForTypedConfig lets main.go handle configuration errors; .env is now read by the loader
+func migrateMainForTypedConfig(plan *upgradePlan) error {
+	mainPath := "cmd/main.go"
+	mainStr, ok := plan.read(mainPath)
+	if !ok {
+		return fmt.Errorf("%s not found", mainPath)
+	}
+
+	dotenvLoad := regexp.MustCompile(`(?s)\t// Load environment variables\n\tif err := godotenv\.Load\(\); err != nil \{\n.*?\n\t\}\n\n`)
+	mainStr = dotenvLoad.ReplaceAllString(mainStr, "")
+	mainStr = strings.Replace(mainStr, "\t\"github.com/joho/godotenv\"\n", "", 1)
+
+	oldLoad := regexp.MustCompile(`(?m)^\t(// Load configuration\n\t)?cfg := config\.LoadConfig\(\)\n`)
+	if !oldLoad.MatchString(mainStr) {
+		plan.note("%s: update the config.LoadConfig() call to handle the returned error", mainPath)
+	}
+	mainStr = oldLoad.ReplaceAllLiteralString(mainStr, `	// Load configuration from the environment, .env and config/<profile>.yaml
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+`)
+	mainStr = strings.ReplaceAll(mainStr, `cfg.RedisURL != ""`, `cfg.Redis().URL != ""`)
+
+	plan.write(mainPath, mainStr)
+	return nil
+}
+
+// migrateAppServerLifecycle regenerates app_server.go from the current template,
+// re-applying the Redis and tracing integrations the old file contained
+func migrateAppServerLifecycle(plan *upgradePlan) error {
+	appServerPath := "cmd/app_server.go"
+	appServerStr, ok := plan.read(appServerPath)
+	if !ok {
+		return fmt.Errorf("%s not found", appServerPath)
+	}
+	if strings.Contains(appServerStr, "func (s *AppServer) OnStop(") {
+		return nil
+	}
+
+	newAppServer, err := utils.RenderTemplate(templates.AppServerTemplate, plan.templateData())
+	if err != nil {
+		return err
+	}
+	if strings.Contains(appServerStr, "redisAdapter") {
+		if newAppServer, err = patchAppServerForRedis(newAppServer, plan.projectName); err != nil {
+			return err
+		}
+	}
+	if strings.Contains(appServerStr, "otelfiber") {
+		if newAppServer, err = patchAppServerForTracing(newAppServer); err != nil {
+			return err
+		}
+	}
+	plan.write(appServerPath, newAppServer)
+	plan.note("%s was regenerated; re-apply any custom middleware or settings shown as removed in the diff", appServerPath)
+
+	if err := plan.create(filepath.Join("util", "health.go"), templates.HealthUtilTemplate, plan.templateData()); err != nil {
+		return err
+	}
+
+	if envExample, ok := plan.read(".env.example"); ok {
+		if !strings.Contains(envExample, "SHUTDOWN_TIMEOUT") {
+			envExample += "\n# Shutdown Configuration\nSHUTDOWN_TIMEOUT=15s\nSHUTDOWN_DRAIN_DELAY=0s\n"
+		}
+		if !strings.Contains(envExample, "HEALTH_CHECK_TIMEOUT") {
+			envExample += "\n# Health Check Configuration\nHEALTH_CHECK_TIMEOUT=2s\nHEALTH_CACHE_TTL=5s\n"
+		}
+		plan.write(".env.example", envExample)
+	}
+
+	return nil
+}
+
+// migrateHealthRoutes removes the static /health route, now served by AppServer,
+// and lets the auth middleware through /health/live and /health/ready
+func migrateHealthRoutes(plan *upgradePlan) error {
+	routePath := filepath.Join("route", "v1.go")
+	if routeStr, ok := plan.read(routePath); ok {
+		staticHealth := regexp.MustCompile(`(?s)\n[ \t]*// Health check\n\tapp\.Get\("/health", func\(c \*fiber\.Ctx\) error \{\n.*?\n\t\}\)\n`)
+		plan.write(routePath, staticHealth.ReplaceAllString(routeStr, "\n"))
+	}
+
+	authPath := filepath.Join("middleware", "auth.go")
+	if authStr, ok := plan.read(authPath); ok && strings.Contains(authStr, `c.Path() == "/health"`) {
+		authStr = strings.Replace(authStr, `c.Path() == "/health"`, `strings.HasPrefix(c.Path(), "/health")`, 1)
+		plan.write(authPath, addImport(authStr, "strings"))
+	}
+
+	return nil
+}
+
+// migrateHandlerUserContext makes handlers pass the request context that carries values and spans
+func migrateHandlerUserContext(plan *upgradePlan) error {
+	handlerFiles, _ := filepath.Glob(filepath.Join("handler", "*.go"))
+	for _, path := range handlerFiles {
+		handlerStr, ok := plan.read(path)
+		if !ok || !strings.Contains(handlerStr, "ctx.Context()") {
+			continue
+		}
+		plan.write(path, strings.ReplaceAll(handlerStr, "ctx.Context()", "ctx.UserContext()"))
+	}
+	return nil
+}
+
+// migrateCorsPatchMethod adds PATCH to the CORS methods, which browsers need to call PATCH endpoints
+func migrateCorsPatchMethod(plan *upgradePlan) error {
+	for _, path := range []string{"cmd/app_server.go", ".env.example"} {
+		content, ok := plan.read(path)
+		if !ok || !strings.Contains(content, "GET,POST,PUT,DELETE,OPTIONS") {
+			continue
+		}
+		plan.write(path, strings.ReplaceAll(content, "GET,POST,PUT,DELETE,OPTIONS", "GET,POST,PUT,PATCH,DELETE,OPTIONS"))
+	}
+	return nil
+}
+
+// redisDBFieldPattern matches the REDIS_DB field the cache namespace is added after
+var redisDBFieldPattern = regexp.MustCompile(`(?m)^\tDB\s+int\s+` + "`" + `env:"REDIS_DB"[^\n]*\n`)
+
+// migrateCacheManagerScan regenerates a CacheManager that still blocks Redis with KEYS or empties
+// the whole database with FLUSHDB, and adds the key namespace it now requires
+func migrateCacheManagerScan(plan *upgradePlan) error {
+	redisUtilPath := filepath.Join("util", "redis_util.go")
+	redisUtil, ok := plan.read(redisUtilPath)
+	if !ok || (!strings.Contains(redisUtil, "FlushDB(") && !strings.Contains(redisUtil, ".Keys(ctx")) {
+		return nil
+	}
+
+	plan.write(redisUtilPath, fmt.Sprintf(templates.RedisUtilTemplate, plan.projectName))
+	if err := plan.create(filepath.Join("util", "redis_util_test.go"), fmt.Sprintf(templates.RedisUtilTestTemplate, plan.projectName), nil); err != nil {
+		return err
+	}
+
+	configPath := filepath.Join("config", "redis_config.go")
+	if configStr, ok := plan.read(configPath); ok && !strings.Contains(configStr, "CacheNamespace") {
+		field := redisDBFieldPattern.FindString(configStr)
+		if field == "" {
+			return fmt.Errorf("could not find the REDIS_DB field in %s", configPath)
+		}
+		plan.write(configPath, strings.Replace(configStr, field, field+
+			"\tCacheNamespace string `env:\"REDIS_CACHE_NAMESPACE\" default:\"cache\"` // Prefix of every CacheManager key\n", 1))
+	}
+
+	if envStr, ok := plan.read(".env.example"); ok && !strings.Contains(envStr, "REDIS_CACHE_NAMESPACE") {
+		plan.write(".env.example", strings.Replace(envStr, "REDIS_DB=0\n", "REDIS_DB=0\nREDIS_CACHE_NAMESPACE=cache\n", 1))
+	}
+
+	goMod, ok := plan.read("go.mod")
+	if !ok {
+		return fmt.Errorf("go.mod not found")
+	}
+	goMod, err := addGoModRequires(goMod, "github.com/alicebob/miniredis/v2 v2.31.1")
+	if err != nil {
+		return err
+	}
+	plan.write("go.mod", goMod)
+
+	plan.note("%s was regenerated: NewCacheManager now takes a key namespace, e.g. util.NewCacheManager(redisAdapter, cfg.Redis().CacheNamespace), and InvalidatePattern patterns are matched inside it", redisUtilPath)
+	return nil
+}
+
+// migrateDependencyVersions raises go.mod requirements that are older than the versions in GoModTemplate
+// and adds direct dependencies of new projects that are missing
+func migrateDependencyVersions(plan *upgradePlan) error {
+	goMod, ok := plan.read("go.mod")
+	if !ok {
+		return fmt.Errorf("go.mod not found")
+	}
+
+	templateGoMod, err := utils.RenderTemplate(templates.GoModTemplate, plan.templateData())
+	if err != nil {
+		return err
+	}
+
+	requireLine := regexp.MustCompile(`(?m)^\t(\S+) (v\S+)( // indirect)?$`)
+	latest := map[string]string{}
+	var direct []string
+	for _, match := range requireLine.FindAllStringSubmatch(templateGoMod, -1) {
+		if current, ok := latest[match[1]]; !ok || utils.CompareVersions(match[2], current) > 0 {
+			latest[match[1]] = match[2]
+		}
+		if match[3] == "" {
+			direct = append(direct, match[1]+" "+match[2])
+		}
+	}
+
+	var bumped []string
+	goMod = requireLine.ReplaceAllStringFunc(goMod, func(line string) string {
+		match := requireLine.FindStringSubmatch(line)
+		version, ok := latest[match[1]]
+		if !ok || utils.CompareVersions(version, match[2]) <= 0 {
+			return line
+		}
+		bumped = append(bumped, fmt.Sprintf("%s %s → %s", match[1], match[2], version))
+		return "\t" + match[1] + " " + version + match[3]
+	})
+
+	if goMod, err = addGoModRequires(goMod, direct...); err != nil {
+		return err
+	}
+
+	if len(bumped) > 0 {
+		sort.Strings(bumped)
+		plan.note("Updated dependencies: %s", strings.Join(bumped, ", "))
+	}
+	plan.write("go.mod", goMod)
+	return nil
+}
@@ -0,0 +1,117 @@
+package generators
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/templates"
+	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/utils"
+)
+
+// GenerateQueryCache creates util/query_cache.go and route/cache.go, shared by cached resources, if missing
+func GenerateQueryCache() error {
+	// Remember reads the transaction from the context
+	if err := GenerateTxUtil(); err != nil {
+		return err
+	}
+	if !utils.FileExists("util/query_cache.go") {
+		if err := utils.WriteFile("util/query_cache.go", templates.QueryCacheUtilTemplate, nil); err != nil {
+			return err
+		}
+	}
+
+	if utils.FileExists("route/cache.go") {
+		return nil
+	}
+	moduleName, err := utils.GetModuleName()
+	if err != nil {
+		return err
+	}
+	return utils.WriteFile("route/cache.go", templates.QueryCacheRouteTemplate, map[string]string{"ProjectName": moduleName})
+}
+
+// generateCachedRepository writes the cache-aside decorator of a resource repository
+func generateCachedRepository(name string, opts ResourceOptions) error {
+	moduleName, err := utils.GetModuleName()
+	if err != nil {
+		return err
+	}
+
+	filename := fmt.Sprintf("repository/%s_cache_repo.go", strings.ToLower(name))
+	return utils.WriteFile(filename, templates.CachedRepositoryTemplate, opts.templateData(name, moduleName))
+}
+
+var (
+	// findByIDPattern captures the primary key type from a repository interface
+	findByIDPattern = regexp.MustCompile(`FindByID\(ctx context\.Context, id ([^,]+),`)
+
+	// primaryKeyFieldPattern captures the primary key field of a model struct
+	primaryKeyFieldPattern = regexp.MustCompile(`(?m)^\s*(\w+)\s+\S+\s+` + "`" + `gorm:"[^"]*primar(?:y_key|yKey)`)
+
+	// tableNamePattern captures the table a model maps to
+	tableNamePattern = regexp.MustCompile(`TableName\(\) string \{\s*return "([^"]+)"`)
+)
+
+// CacheResource adds the cache-aside decorator to an existing resource, reading the key type and
+// optional methods from its repository and model, and wraps the repository in its route file
+func CacheResource(name string) ([]string, error) {
+	lower := strings.ToLower(name)
+	repoPath := fmt.Sprintf("repository/%s_repo.go", lower)
+	repoSrc, err := os.ReadFile(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("repository not found at %s", repoPath)
+	}
+	modelPath := fmt.Sprintf("model/%s.go", lower)
+	modelSrc, err := os.ReadFile(modelPath)
+	if err != nil {
+		return nil, fmt.Errorf("model not found at %s", modelPath)
+	}
+
+	opts := ResourceOptions{
+		TableName:  lower + "s",
+		SoftDelete: strings.Contains(string(repoSrc), "FindTrashed("),
+		Versioned:  strings.Contains(string(repoSrc), "DeleteVersion("),
+		Cached:     true,
+	}
+	match := findByIDPattern.FindStringSubmatch(string(repoSrc))
+	if match == nil {
+		return nil, fmt.Errorf("could not find FindByID in %s", repoPath)
+	}
+	opts.PrimaryKey.Type = strings.TrimSpace(match[1])
+	if match = primaryKeyFieldPattern.FindStringSubmatch(string(modelSrc)); match == nil {
+		return nil, fmt.Errorf("could not find the primary key field in %s", modelPath)
+	}
+	opts.PrimaryKey.Name = match[1]
+	if match = tableNamePattern.FindStringSubmatch(string(modelSrc)); match != nil {
+		opts.TableName = match[1]
+	}
+
+	if err := GenerateQueryCache(); err != nil {
+		return nil, err
+	}
+	if err := generateCachedRepository(name, opts); err != nil {
+		return nil, err
+	}
+	files := []string{fmt.Sprintf("repository/%s_cache_repo.go", lower)}
+
+	routePath := fmt.Sprintf("route/%s.go", lower)
+	routeSrc, err := os.ReadFile(routePath)
+	if err != nil {
+		return nil, fmt.Errorf("route file not found at %s", routePath)
+	}
+	if strings.Contains(string(routeSrc), "NewCached"+name+"Repository") {
+		return files, nil
+	}
+
+	repoInit := fmt.Sprintf("\t%sRepo := repository.New%sRepository(db)\n", lower, name)
+	if !strings.Contains(string(routeSrc), repoInit) {
+		return nil, fmt.Errorf("could not find repository initialization in %s", routePath)
+	}
+	cachedInit := repoInit + fmt.Sprintf("\tif queryCache != nil {\n\t\t%sRepo = repository.NewCached%sRepository(%sRepo, db, queryCache)\n\t}\n", lower, name, lower)
+	if err := os.WriteFile(routePath, []byte(strings.Replace(string(routeSrc), repoInit, cachedInit, 1)), 0644); err != nil {
+		return nil, err
+	}
+	return append(files, routePath), nil
+}
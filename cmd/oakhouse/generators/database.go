@@ -0,0 +1,409 @@
+package generators
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/utils"
+	_ "github.com/lib/pq"
+)
+
+// DBTable describes an existing PostgreSQL table read from information_schema and pg_catalog
+type DBTable struct {
+	Schema     string
+	Name       string
+	Columns    []DBColumn
+	PrimaryKey []string
+}
+
+// DBColumn describes a table column with its type, constraints and indexes
+type DBColumn struct {
+	Name       string
+	DataType   string
+	UDTName    string
+	Nullable   bool
+	Default    string
+	MaxLength  int
+	Precision  int
+	Scale      int
+	References *DBReference
+	Indexes    []DBIndex
+}
+
+// DBReference is the target of a single-column foreign key
+type DBReference struct {
+	Table      string
+	Column     string
+	PrimaryKey bool
+}
+
+// DBIndex is a non-primary index that covers a column
+type DBIndex struct {
+	Name   string
+	Unique bool
+}
+
+// IntrospectTables reads the structure of the given tables from a PostgreSQL database.
+// All base tables in the schema are returned when no table names are given.
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+func IntrospectTables(dsn, schema string, tables []string) ([]DBTable, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	defer db.Close()
+
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	if len(tables) == 0 {
+		if tables, err = listTables(db, schema); err != nil {
+			return nil, err
+		}
+	}
+
+	var result []DBTable
+	for _, name := range tables {
+		table, err := introspectTable(db, schema, name)
+		if err != nil {
+			return nil, fmt.Errorf("table %s.%s: %w", schema, name, err)
+		}
+		result = append(result, *table)
+	}
+	return result, nil
+}
+
+// listTables returns the names of all base tables in a schema
+func listTables(db *sql.DB, schema string) ([]string, error) {
+	rows, err := db.Query(`
+		SELECT table_name
+		FROM information_schema.tables
+		WHERE table_schema = $1 AND table_type = 'BASE TABLE'
+		ORDER BY table_name`, schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		tables = append(tables, name)
+	}
+	return tables, rows.Err()
+}
+
+// introspectTable reads the columns, primary key, foreign keys and indexes of one table
+func introspectTable(db *sql.DB, schema, name string) (*DBTable, error) {
+	table := &DBTable{Schema: schema, Name: name}
+
+	// Columns
+	rows, err := db.Query(`
+		SELECT column_name, data_type, udt_name, is_nullable = 'YES',
+			COALESCE(column_default, ''), COALESCE(character_maximum_length, 0),
+			COALESCE(numeric_precision, 0), COALESCE(numeric_scale, 0)
+		FROM information_schema.columns
+		WHERE table_schema = $1 AND table_name = $2
+		ORDER BY ordinal_position`, schema, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns: %w", err)
+	}
+	defer rows.Close()
+
+	columns := make(map[string]int)
+	for rows.Next() {
+		var column DBColumn
+		if err := rows.Scan(&column.Name, &column.DataType, &column.UDTName, &column.Nullable,
+			&column.Default, &column.MaxLength, &column.Precision, &column.Scale); err != nil {
+			return nil, err
+		}
+		columns[column.Name] = len(table.Columns)
+		table.Columns = append(table.Columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(table.Columns) == 0 {
+		return nil, fmt.Errorf("table not found")
+	}
+
+	// Primary and foreign keys, read from pg_catalog because information_schema only
+	// reports constraint targets for tables owned by the current role
+	rows, err = db.Query(`
+		SELECT c.contype, a.attname, array_length(c.conkey, 1),
+			COALESCE(rt.relname, ''), COALESCE(ra.attname, ''),
+			COALESCE(ra.attnum = ANY(pk.conkey), false)
+		FROM pg_constraint c
+		JOIN pg_class t ON t.oid = c.conrelid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = ANY(c.conkey)
+		LEFT JOIN pg_class rt ON rt.oid = c.confrelid
+		LEFT JOIN pg_attribute ra ON ra.attrelid = c.confrelid AND ra.attnum = c.confkey[1]
+		LEFT JOIN pg_constraint pk ON pk.conrelid = c.confrelid AND pk.contype = 'p'
+		WHERE n.nspname = $1 AND t.relname = $2 AND c.contype IN ('p', 'f')
+		ORDER BY c.contype DESC, array_position(c.conkey, a.attnum)`, schema, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read constraints: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var constraintType, column, refTable, refColumn string
+		var size int
+		var refPrimary bool
+		if err := rows.Scan(&constraintType, &column, &size, &refTable, &refColumn, &refPrimary); err != nil {
+			return nil, err
+		}
+		i, ok := columns[column]
+		if !ok {
+			continue
+		}
+		if constraintType == "p" {
+			table.PrimaryKey = append(table.PrimaryKey, column)
+		} else if size == 1 {
+			// Composite foreign keys have no single-column association and are left as plain fields
+			table.Columns[i].References = &DBReference{Table: refTable, Column: refColumn, PrimaryKey: refPrimary}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Indexes are not part of information_schema, so they are read from pg_catalog
+	rows, err = db.Query(`
+		SELECT i.relname, ix.indisunique, a.attname
+		FROM pg_class t
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		JOIN pg_index ix ON ix.indrelid = t.oid
+		JOIN pg_class i ON i.oid = ix.indexrelid
+		JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = ANY(ix.indkey)
+		WHERE n.nspname = $1 AND t.relname = $2 AND NOT ix.indisprimary
+		ORDER BY i.relname, array_position(ix.indkey::int2[], a.attnum)`, schema, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read indexes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var index DBIndex
+		var column string
+		if err := rows.Scan(&index.Name, &index.Unique, &column); err != nil {
+			return nil, err
+		}
+		if i, ok := columns[column]; ok {
+			table.Columns[i].Indexes = append(table.Columns[i].Indexes, index)
+		}
+	}
+	return table, rows.Err()
+}
+
+// ResourceNameForTable derives a singular PascalCase resource name from a table name
+func ResourceNameForTable(table string) string {
+	name := strings.ToLower(table)
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		name = strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "sses"), strings.HasSuffix(name, "uses"), strings.HasSuffix(name, "xes"),
+		strings.HasSuffix(name, "ches"), strings.HasSuffix(name, "shes"):
+		name = strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss") && !strings.HasSuffix(name, "us"):
+		name = strings.TrimSuffix(name, "s")
+	}
+	return fieldNameForColumn(name)
+}
+
+var nonIdentifierChars = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+// fieldNameForColumn converts a column name such as "serial_number" into a Go field name
+func fieldNameForColumn(column string) string {
+	name := utils.ToPascalCase(strings.ToLower(nonIdentifierChars.ReplaceAllString(column, "_")))
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "Col" + name
+	}
+	return name
+}
+
+// ResourceOptions maps the table onto resource generator options.
+// models maps the names of tables generated in the same run to their resource names so
+// foreign keys between them become belongs-to associations.
+func (t DBTable) ResourceOptions(models map[string]string) (ResourceOptions, error) {
+	if len(t.PrimaryKey) != 1 {
+		if len(t.PrimaryKey) == 0 {
+			return ResourceOptions{}, fmt.Errorf("table %s has no primary key", t.Name)
+		}
+		return ResourceOptions{}, fmt.Errorf("table %s has a composite primary key (%s), which is not supported", t.Name, strings.Join(t.PrimaryKey, ", "))
+	}
+
+	opts := ResourceOptions{TableName: t.Name}
+	if t.Schema != "" && t.Schema != "public" {
+		opts.TableName = t.Schema + "." + t.Name
+	}
+
+	taken := map[string]bool{"ID": true, "CreatedAt": true, "UpdatedAt": true, "DeletedAt": true}
+	for _, column := range t.Columns {
+		goType := utils.MapSQLType(column.DataType, column.UDTName)
+
+		if column.Name == t.PrimaryKey[0] {
+			key, assigned, err := primaryKeyField(column, goType)
+			if err != nil {
+				return ResourceOptions{}, fmt.Errorf("table %s: %w", t.Name, err)
+			}
+			opts.PrimaryKey, opts.AssignedKey = key, assigned
+			continue
+		}
+
+		// Conventional timestamp columns map onto GORM's automatic timestamps and soft delete
+		isTimestamp := goType == "time.Time" && column.DataType != "date"
+		switch {
+		case column.Name == "created_at" && isTimestamp && !column.Nullable:
+			opts.CreatedAt = true
+			continue
+		case column.Name == "updated_at" && isTimestamp && !column.Nullable:
+			opts.UpdatedAt = true
+			continue
+		case column.Name == "deleted_at" && isTimestamp && column.Nullable:
+			opts.SoftDelete = true
+			continue
+		}
+
+		name := fieldNameForColumn(column.Name)
+		for taken[name] {
+			name += "Col"
+		}
+		taken[name] = true
+
+		field := utils.Field{
+			Name:     name,
+			Type:     goType,
+			GormTag:  columnGormTag(column),
+			JsonTag:  column.Name,
+			QueryTag: column.Name,
+			Column:   column.Name,
+			Nullable: column.Nullable,
+		}
+		// JSON, binary and array columns cannot be filtered or sorted with a query
+		// parameter and are already nil when NULL, so they are not wrapped in a pointer
+		if goType == "json.RawMessage" || goType == "[]byte" || strings.HasPrefix(goType, "pq.") {
+			field.NoPointer = true
+		} else {
+			field.QueryType = "*" + goType
+		}
+		opts.Fields = append(opts.Fields, field)
+	}
+
+	// Foreign keys to the primary key of other generated tables become belongs-to associations
+	for _, field := range opts.Fields {
+		column := t.column(field.Column)
+		if column.References == nil || !column.References.PrimaryKey {
+			continue
+		}
+		model, ok := models[column.References.Table]
+		if !ok {
+			continue
+		}
+		relation := fieldNameForColumn(strings.TrimSuffix(field.Column, "_id"))
+		if relation == field.Name || taken[relation] {
+			continue
+		}
+		taken[relation] = true
+		opts.Relations = append(opts.Relations, Relation{
+			Name:       relation,
+			Model:      model,
+			ForeignKey: field.Name,
+			JsonTag:    strings.TrimSuffix(field.Column, "_id"),
+		})
+	}
+
+	return opts, nil
+}
+
+// column returns the column with the given name
+func (t DBTable) column(name string) DBColumn {
+	for _, column := range t.Columns {
+		if column.Name == name {
+			return column
+		}
+	}
+	return DBColumn{}
+}
+
+// primaryKeyField maps the primary key column and reports whether clients must assign it
+func primaryKeyField(column DBColumn, goType string) (utils.Field, bool, error) {
+	field := utils.Field{
+		Name:    "ID",
+		Type:    goType,
+		JsonTag: column.Name,
+		Column:  column.Name,
+	}
+	tag := []string{"column:" + column.Name, "type:" + sqlColumnType(column), "primaryKey"}
+
+	generated := column.Default != ""
+	switch field.Type {
+	case "uuid.UUID":
+		if generated {
+			tag = append(tag, "default:"+column.Default)
+		}
+	case "int16", "int32", "int64":
+		// Serial and identity columns are generated by the database
+		if generated {
+			tag = append(tag, "autoIncrement")
+		} else {
+			tag = append(tag, "autoIncrement:false")
+		}
+	case "string":
+		if generated {
+			tag = append(tag, "default:"+column.Default)
+		}
+	default:
+		return utils.Field{}, false, fmt.Errorf("primary key %s has unsupported type %s", column.Name, column.DataType)
+	}
+
+	field.GormTag = strings.Join(tag, ";")
+	return field, !generated, nil
+}
+
+// columnGormTag builds the GORM tag for a column, keeping its type, nullability and indexes
+func columnGormTag(column DBColumn) string {
+	tag := []string{"column:" + column.Name, "type:" + sqlColumnType(column)}
+	if !column.Nullable {
+		tag = append(tag, "not null")
+	}
+	for _, index := range column.Indexes {
+		if index.Unique {
+			tag = append(tag, "uniqueIndex:"+index.Name)
+		} else {
+			tag = append(tag, "index:"+index.Name)
+		}
+	}
+	return strings.Join(tag, ";")
+}
+
+// sqlColumnType renders the column type as it would appear in a CREATE TABLE statement
+func sqlColumnType(column DBColumn) string {
+	switch column.DataType {
+	case "character varying":
+		if column.MaxLength > 0 {
+			return fmt.Sprintf("varchar(%d)", column.MaxLength)
+		}
+		return "varchar"
+	case "character":
+		return fmt.Sprintf("char(%d)", column.MaxLength)
+	case "numeric":
+		if column.Precision > 0 {
+			return fmt.Sprintf("numeric(%d,%d)", column.Precision, column.Scale)
+		}
+		return "numeric"
+	case "ARRAY":
+		return strings.TrimPrefix(column.UDTName, "_") + "[]"
+	case "USER-DEFINED":
+		return column.UDTName
+	default:
+		return column.DataType
+	}
+}
@@ -14,36 +14,64 @@ import (
 // Ensures clean separation between API contracts and internal data models.
 // 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
 func GenerateDTO(name string, fields []string) error {
+	return generateDTO(name, NewResourceOptions(name, fields))
+}
+
+// generateDTO renders the Create, Update, Get, Response and Bulk DTOs for a table mapping
+func generateDTO(name string, opts ResourceOptions) error {
 	dtoDir := fmt.Sprintf("dto/%s", strings.ToLower(name))
 	if err := os.MkdirAll(dtoDir, 0755); err != nil {
 		return fmt.Errorf("failed to create DTO directory: %w", err)
 	}
 
-	// Parse fields for template
-	parsedFields := utils.ParseFields(fields)
-
 	// Get module name from go.mod
 	moduleName, err := utils.GetModuleName()
 	if err != nil {
 		return fmt.Errorf("failed to get module name: %w", err)
 	}
 
+	// Collect the field types each DTO declares so only the needed packages are imported
+	var createTypes, updateTypes, getTypes []string
+	responseTypes := []string{opts.PrimaryKey.Type}
+	if opts.CreatedAt || opts.UpdatedAt || opts.SoftDelete {
+		responseTypes = append(responseTypes, "time.Time")
+	}
+	if opts.AssignedKey {
+		createTypes = append(createTypes, opts.PrimaryKey.Type)
+	}
+	if opts.CreatedAt {
+		getTypes = append(getTypes, "time.Time")
+	}
+	for _, field := range opts.Fields {
+		createTypes = append(createTypes, field.Type)
+		updateTypes = append(updateTypes, field.Type)
+		responseTypes = append(responseTypes, field.Type)
+	}
+	for _, field := range opts.queryFields() {
+		getTypes = append(getTypes, field.Type)
+	}
+
 	dtoTemplates := map[string]string{
-		"create": templates.CreateDtoTemplate,
-		"update": templates.UpdateDtoTemplate,
-		"get":    templates.GetDtoTemplate,
+		"create":   templates.CreateDtoTemplate,
+		"update":   templates.UpdateDtoTemplate,
+		"get":      templates.GetDtoTemplate,
+		"response": templates.ResponseDtoTemplate,
+		"bulk":     templates.BulkDtoTemplate,
+	}
+	dtoImports := map[string][]string{
+		"create":   typeImports(createTypes...),
+		"update":   typeImports(updateTypes...),
+		"get":      typeImports(getTypes...),
+		"response": typeImports(responseTypes...),
+		"bulk":     typeImports(opts.PrimaryKey.Type),
 	}
 
 	for dtoType, tmpl := range dtoTemplates {
 		filename := fmt.Sprintf("%s/%s_%s_dto.go", dtoDir, dtoType, strings.ToLower(name))
-		if err := utils.WriteFile(filename, tmpl, map[string]interface{}{
-			"ProjectName": moduleName,
-			"ModelName":   name,
-			"PackageName": strings.ToLower(name),
-			"VarName":     strings.ToLower(name),
-			"Type":        strings.Title(dtoType),
-			"Fields":      parsedFields,
-		}); err != nil {
+		data := opts.templateData(name, moduleName)
+		data["Type"] = strings.Title(dtoType)
+		data["Imports"] = dtoImports[dtoType]
+		if err := utils.WriteFile(filename, tmpl, data); err != nil {
 			return err
 		}
 	}
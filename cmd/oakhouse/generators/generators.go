@@ -5,6 +5,7 @@ package generators
 //
 // - project.go: Project creation and initialization
 // - resource.go: Complete CRUD resource generation
+// - database.go: Table introspection for resources generated from existing databases
 // - model.go: Database model generation
 // - repository.go: Repository layer generation
 // - service.go: Service layer generation
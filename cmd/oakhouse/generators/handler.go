@@ -13,6 +13,11 @@ import (
 // request validation, error handling, and JSON responses following REST conventions.
 // 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
 func GenerateHandler(name string) error {
+	return generateHandler(name, NewResourceOptions(name, nil))
+}
+
+// generateHandler renders the REST handler for a table mapping, parsing ids as its primary key type
+func generateHandler(name string, opts ResourceOptions) error {
 	filename := fmt.Sprintf("handler/%s_handler.go", strings.ToLower(name))
 	// Get module name from go.mod
 	moduleName, err := utils.GetModuleName()
@@ -20,12 +25,35 @@ func GenerateHandler(name string) error {
 		return fmt.Errorf("failed to get module name: %w", err)
 	}
 
-	return utils.WriteFile(filename, templates.HandlerTemplate, map[string]interface{}{
-		"ProjectName": moduleName,
-		"ModelName":   name,
-		"PackageName": strings.ToLower(name),
-		"VarName":     strings.ToLower(name),
-	})
+	// FindAll trims responses to the requested fields with the shared projection helper
+	if err := GenerateFieldsUtil(); err != nil {
+		return err
+	}
+
+	// Patch applies merge and JSON patches with the shared patch helpers
+	if err := GeneratePatchUtil(); err != nil {
+		return err
+	}
+
+	return utils.WriteFile(filename, templates.HandlerTemplate, opts.templateData(name, moduleName))
+}
+
+// GenerateFieldsUtil creates util/fields.go with the sparse fieldset helper if it is missing
+func GenerateFieldsUtil() error {
+	filename := "util/fields.go"
+	if utils.FileExists(filename) {
+		return nil
+	}
+	return utils.WriteFile(filename, templates.FieldsUtilTemplate, nil)
+}
+
+// GeneratePatchUtil creates util/patch.go with the JSON Merge Patch and JSON Patch helpers if it is missing
+func GeneratePatchUtil() error {
+	filename := "util/patch.go"
+	if utils.FileExists(filename) {
+		return nil
+	}
+	return utils.WriteFile(filename, templates.PatchUtilTemplate, nil)
 }
 
 func GenerateSimpleHandler(name string) error {
@@ -13,10 +13,26 @@ import (
 // Fields are parsed and mapped to appropriate Go types with validation tags.
 // 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
 func GenerateModel(name string, fields []string) error {
+	return generateModel(name, NewResourceOptions(name, fields))
+}
+
+// generateModel renders the model for a table mapping, importing only the packages its columns use
+func generateModel(name string, opts ResourceOptions) error {
 	filename := fmt.Sprintf("model/%s.go", strings.ToLower(name))
-	return utils.WriteFile(filename, templates.ModelTemplate, map[string]interface{}{
-		"ModelName": name,
-		"TableName": strings.ToLower(name) + "s",
-		"Fields":    utils.ParseFields(fields),
-	})
+
+	types := []string{opts.PrimaryKey.Type}
+	for _, field := range opts.Fields {
+		types = append(types, field.Type)
+	}
+	if opts.CreatedAt || opts.UpdatedAt {
+		types = append(types, "time.Time")
+	}
+	imports := typeImports(types...)
+	if opts.SoftDelete {
+		imports = append(imports, "gorm.io/gorm")
+	}
+
+	data := opts.templateData(name, "")
+	data["Imports"] = imports
+	return utils.WriteFile(filename, templates.ModelTemplate, data)
 }
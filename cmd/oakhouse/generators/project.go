@@ -49,6 +49,7 @@ func CreateNewProject(projectName string) error {
 	// Generate project files
 	files := map[string]string{
 		"go.mod":             templates.GoModTemplate,
+		".oakhouse.json":     templates.ProjectManifestTemplate,
 		".env.example":       templates.EnvExampleTemplate,
 		"Dockerfile":         templates.DockerfileTemplate,
 		"docker-compose.yml": templates.DockerComposeTemplate,
@@ -57,11 +58,15 @@ func CreateNewProject(projectName string) error {
 		"cmd/wire.go":        templates.WireTemplate,
 
 		"config/env_config.go":        templates.EnvConfigTemplate,
+		"config/loader.go":            templates.ConfigLoaderTemplate,
+		"config/development.yaml":     templates.DevelopmentProfileTemplate,
+		"config/production.yaml":      templates.ProductionProfileTemplate,
 		"route/v1.go":                 templates.RouteTemplate,
 		"adapter/database_adapter.go": templates.DatabaseAdapterTemplate,
 		"adapter/postgres/gorm.go":    templates.PostgresAdapterTemplate,
 		"util/response.go":            templates.ResponseUtilTemplate,
 		"util/pagination.go":          templates.PaginationUtilTemplate,
+		"util/health.go":              templates.HealthUtilTemplate,
 		"scope/base_scope.go":         templates.BaseScopeTemplate,
 		"middleware/auth.go":          templates.AuthMiddlewareTemplate,
 		"static/index.html":           templates.IndexHtmlTemplate,
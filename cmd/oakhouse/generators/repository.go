@@ -13,6 +13,11 @@ import (
 // Follows repository pattern for clean separation between business logic and data access.
 // 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
 func GenerateRepository(name string) error {
+	return generateRepository(name, NewResourceOptions(name, nil))
+}
+
+// generateRepository renders the repository for a table mapping, keyed by its primary key column
+func generateRepository(name string, opts ResourceOptions) error {
 	filename := fmt.Sprintf("repository/%s_repo.go", strings.ToLower(name))
 	// Get module name from go.mod
 	moduleName, err := utils.GetModuleName()
@@ -20,10 +25,33 @@ func GenerateRepository(name string) error {
 		return fmt.Errorf("failed to get module name: %w", err)
 	}
 
-	return utils.WriteFile(filename, templates.RepositoryInterfaceTemplate+"\n\n"+templates.RepositoryImplTemplate, map[string]interface{}{
-		"ProjectName": moduleName,
-		"ModelName":   name,
-		"PackageName": strings.ToLower(name),
-		"VarName":     strings.ToLower(name),
-	})
+	// FindWithCursor relies on the shared keyset paginator
+	if err := GenerateCursorUtil(); err != nil {
+		return err
+	}
+
+	// Repositories run in the transaction carried by the context
+	if err := GenerateTxUtil(); err != nil {
+		return err
+	}
+
+	return utils.WriteFile(filename, templates.RepositoryInterfaceTemplate+"\n\n"+templates.RepositoryImplTemplate, opts.templateData(name, moduleName))
+}
+
+// GenerateCursorUtil creates util/cursor.go with the keyset paginator if it is missing
+func GenerateCursorUtil() error {
+	filename := "util/cursor.go"
+	if utils.FileExists(filename) {
+		return nil
+	}
+	return utils.WriteFile(filename, templates.CursorUtilTemplate, nil)
+}
+
+// GenerateTxUtil creates util/transaction.go with the transaction manager if it is missing
+func GenerateTxUtil() error {
+	filename := "util/transaction.go"
+	if utils.FileExists(filename) {
+		return nil
+	}
+	return utils.WriteFile(filename, templates.TxUtilTemplate, nil)
 }
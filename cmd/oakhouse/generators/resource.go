@@ -2,69 +2,274 @@ package generators
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+
+	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/utils"
 )
 
+// ResourceOptions describes how a resource maps onto its database table.
+// NewResourceOptions returns the defaults used by 'generate resource' (UUID key, timestamps
+// and soft delete); 'generate from-db' fills it from an existing table instead.
+type ResourceOptions struct {
+	TableName   string
+	PrimaryKey  utils.Field
+	AssignedKey bool // primary key has no database default and is supplied on create
+	Fields      []utils.Field
+	Relations   []Relation
+	CreatedAt   bool
+	UpdatedAt   bool
+	SoftDelete  bool
+	Versioned   bool           // optimistic locking with a version column and ETags
+	Cached      bool           // repository wrapped with the Redis cache-aside decorator
+	Search      *SearchOptions // full-text search, nil when the resource is not searchable
+}
+
+// Relation is a belongs-to association rendered on the model for a foreign key column
+type Relation struct {
+	Name       string
+	Model      string
+	ForeignKey string
+	JsonTag    string
+}
+
+// NewResourceOptions builds the options for a new table from command line field definitions
+func NewResourceOptions(name string, fields []string) ResourceOptions {
+	return ResourceOptions{
+		TableName: strings.ToLower(name) + "s",
+		PrimaryKey: utils.Field{
+			Name:    "ID",
+			Type:    "uuid.UUID",
+			GormTag: "type:uuid;primary_key;default:gen_random_uuid()",
+			JsonTag: "id",
+			Column:  "id",
+		},
+		Fields:     utils.ParseFields(fields),
+		CreatedAt:  true,
+		UpdatedAt:  true,
+		SoftDelete: true,
+	}
+}
+
+// IDKind classifies the primary key type as "uuid", "int" or "string" for templates that parse it
+func (o ResourceOptions) IDKind() string {
+	switch o.PrimaryKey.Type {
+	case "uuid.UUID":
+		return "uuid"
+	case "int", "int16", "int32", "int64", "uint", "uint32", "uint64":
+		return "int"
+	default:
+		return "string"
+	}
+}
+
+// reservedQueryParams are the list parameters of every Get DTO; fields with the same name get no
+// equality parameter of their own and are filtered through the filter grammar instead
+var reservedQueryParams = map[string]bool{
+	"page": true, "pagesize": true, "sort": true, "cursor": true, "limit": true,
+	"count": true, "fields": true, "filters": true, "startdate": true, "enddate": true, "q": true,
+}
+
+// queryFields returns the fields exposed as equality query parameters on the Get DTO
+func (o ResourceOptions) queryFields() []utils.Field {
+	var fields []utils.Field
+	for _, field := range o.Fields {
+		name := strings.ToLower(strings.ReplaceAll(field.QueryTag, "_", ""))
+		if field.QueryType == "" || reservedQueryParams[strings.ToLower(field.Name)] || reservedQueryParams[name] {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// templateData returns the values shared by all resource templates
+func (o ResourceOptions) templateData(name, moduleName string) map[string]interface{} {
+	return map[string]interface{}{
+		"ProjectName": moduleName,
+		"ModelName":   name,
+		"PackageName": strings.ToLower(name),
+		"VarName":     strings.ToLower(name),
+		"TableName":   o.TableName,
+		"PrimaryKey":  o.PrimaryKey,
+		"AssignedKey": o.AssignedKey,
+		"IDKind":      o.IDKind(),
+		"Fields":      o.Fields,
+		"QueryFields": o.queryFields(),
+		"Relations":   o.Relations,
+		"CreatedAt":   o.CreatedAt,
+		"UpdatedAt":   o.UpdatedAt,
+		"SoftDelete":  o.SoftDelete,
+		"Versioned":   o.Versioned,
+		"Cached":      o.Cached,
+		"Search":      o.Search,
+	}
+}
+
+// typeImports returns the import paths needed by the given Go types, standard library first
+func typeImports(types ...string) []string {
+	packages := map[string]string{
+		"time.": "time",
+		"uuid.": "github.com/google/uuid",
+		"json.": "encoding/json",
+		"pq.":   "github.com/lib/pq",
+	}
+
+	seen := make(map[string]bool)
+	var imports []string
+	for _, t := range types {
+		for prefix, path := range packages {
+			if strings.Contains(t, prefix) && !seen[path] {
+				seen[path] = true
+				imports = append(imports, path)
+			}
+		}
+	}
+	sort.Slice(imports, func(i, j int) bool {
+		iStd, jStd := !strings.Contains(imports[i], "."), !strings.Contains(imports[j], ".")
+		if iStd != jStd {
+			return iStd
+		}
+		return imports[i] < imports[j]
+	})
+	return imports
+}
+
 // generateResource generates a complete REST resource including model, service, handler, DTOs and routes.
 // This provides a full CRUD implementation following clean architecture principles with proper separation of concerns.
 // Returns a list of all created files for user feedback.
 // 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
 func GenerateResource(name string, fields []string) ([]string, error) {
+	return GenerateResourceWithOptions(name, NewResourceOptions(name, fields))
+}
+
+// GenerateResourceWithOptions generates a complete REST resource for an explicit table mapping.
+// Used by 'generate from-db' for existing tables with their own keys, column names and timestamps.
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+func GenerateResourceWithOptions(name string, opts ResourceOptions) ([]string, error) {
 	var createdFiles []string
 
-	if err := GenerateModel(name, fields); err != nil {
+	if err := generateModel(name, opts); err != nil {
 		return nil, err
 	}
 	createdFiles = append(createdFiles, fmt.Sprintf("model/%s.go", strings.ToLower(name)))
 
-	if err := GenerateRepository(name); err != nil {
+	if err := generateRepository(name, opts); err != nil {
 		return nil, err
 	}
 	createdFiles = append(createdFiles, fmt.Sprintf("repository/%s_repo.go", strings.ToLower(name)))
 
 	// Generate service interface
-	if err := GenerateServiceInterface(name); err != nil {
+	if err := generateServiceInterface(name, opts); err != nil {
 		return nil, err
 	}
 	createdFiles = append(createdFiles, fmt.Sprintf("service/%s_interface.go", strings.ToLower(name)))
 
 	// Generate service implementation
-	if err := GenerateService(name, fields); err != nil {
+	if err := generateService(name, opts); err != nil {
 		return nil, err
 	}
 	createdFiles = append(createdFiles, fmt.Sprintf("service/%s_service.go", strings.ToLower(name)))
 
-	if err := GenerateHandler(name); err != nil {
+	if err := generateHandler(name, opts); err != nil {
 		return nil, err
 	}
 	createdFiles = append(createdFiles, fmt.Sprintf("handler/%s_handler.go", strings.ToLower(name)))
 
-	if err := GenerateDTO(name, fields); err != nil {
+	if err := generateDTO(name, opts); err != nil {
 		return nil, err
 	}
 	createdFiles = append(createdFiles, fmt.Sprintf("dto/%s/create_%s_dto.go", strings.ToLower(name), strings.ToLower(name)))
 	createdFiles = append(createdFiles, fmt.Sprintf("dto/%s/update_%s_dto.go", strings.ToLower(name), strings.ToLower(name)))
 	createdFiles = append(createdFiles, fmt.Sprintf("dto/%s/get_%s_dto.go", strings.ToLower(name), strings.ToLower(name)))
+	createdFiles = append(createdFiles, fmt.Sprintf("dto/%s/response_%s_dto.go", strings.ToLower(name), strings.ToLower(name)))
+	createdFiles = append(createdFiles, fmt.Sprintf("dto/%s/bulk_%s_dto.go", strings.ToLower(name), strings.ToLower(name)))
 
-	// Add scope generation - this was missing! 
+	// Add scope generation - this was missing!
 	if err := GenerateScope(name, "filter"); err != nil {
 		return nil, err
 	}
 	createdFiles = append(createdFiles, fmt.Sprintf("scope/%s/filter.go", strings.ToLower(name)))
 
-	// Generate field-specific filters for each field
-	for _, field := range fields {
-		parts := strings.Split(field, ":")
-		if len(parts) == 2 {
-			fieldName := strings.Title(parts[0])
-			fieldType := parts[1]
-			if err := GenerateFieldFilter(name, fieldName, fieldType); err != nil {
-				return nil, fmt.Errorf("failed to generate field filter for %s: %w", fieldName, err)
-			}
+	// Generate the shared sort parser and the resource's sortable columns
+	if err := GenerateSortScope(); err != nil {
+		return nil, err
+	}
+	if err := generateResourceSort(name, opts); err != nil {
+		return nil, err
+	}
+	createdFiles = append(createdFiles, fmt.Sprintf("scope/%s/sort.go", strings.ToLower(name)))
+
+	// Generate the shared fields parser and the resource's selectable columns
+	if err := GenerateSelectScope(); err != nil {
+		return nil, err
+	}
+	if err := generateResourceFields(name, opts); err != nil {
+		return nil, err
+	}
+	createdFiles = append(createdFiles, fmt.Sprintf("scope/%s/fields.go", strings.ToLower(name)))
+
+	// Generate the shared filter grammar and the resource's filterable columns
+	if err := GenerateFilterScope(); err != nil {
+		return nil, err
+	}
+	if err := generateResourceFilters(name, opts); err != nil {
+		return nil, err
+	}
+	createdFiles = append(createdFiles, fmt.Sprintf("scope/%s/filters.go", strings.ToLower(name)))
+
+	// Generate full-text search over the searchable columns
+	if opts.Search != nil {
+		if err := GenerateSearchScope(); err != nil {
+			return nil, err
+		}
+		if err := generateResourceSearch(name, opts); err != nil {
+			return nil, err
+		}
+		createdFiles = append(createdFiles, fmt.Sprintf("scope/%s/search.go", strings.ToLower(name)))
+
+		migrations, err := generateSearchMigration(opts)
+		if err != nil {
+			return nil, err
+		}
+		createdFiles = append(createdFiles, migrations...)
+	}
+
+	// Generate the optimistic locking helpers and the version column
+	if opts.Versioned {
+		if err := GenerateETagUtil(); err != nil {
+			return nil, err
+		}
+
+		migrations, err := generateVersionMigration(opts)
+		if err != nil {
+			return nil, err
+		}
+		createdFiles = append(createdFiles, migrations...)
+	}
+
+	// Generate the cache-aside repository decorator
+	if opts.Cached {
+		if err := GenerateQueryCache(); err != nil {
+			return nil, err
+		}
+		if err := generateCachedRepository(name, opts); err != nil {
+			return nil, err
+		}
+		createdFiles = append(createdFiles, fmt.Sprintf("repository/%s_cache_repo.go", strings.ToLower(name)))
+	}
+
+	// Generate field-specific filters for each filterable field
+	for _, field := range opts.Fields {
+		if field.QueryType == "" {
+			continue
+		}
+		if err := generateFieldFilter(name, field); err != nil {
+			return nil, fmt.Errorf("failed to generate field filter for %s: %w", field.Name, err)
 		}
 	}
 
-	if err := GenerateRoute(name); err != nil {
+	if err := generateRoute(name, opts); err != nil {
 		return nil, err
 	}
 	createdFiles = append(createdFiles, fmt.Sprintf("route/%s.go", strings.ToLower(name)))
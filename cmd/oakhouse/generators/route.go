@@ -14,6 +14,16 @@ import (
 // Automatically registers routes in the main router for immediate API availability.
 // 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
 func GenerateRoute(name string) error {
+	// Only route the trash endpoints when the handler implements them
+	opts := NewResourceOptions(name, nil)
+	handler, err := os.ReadFile(fmt.Sprintf("handler/%s_handler.go", strings.ToLower(name)))
+	opts.SoftDelete = err == nil && strings.Contains(string(handler), ") Trashed(")
+
+	return generateRoute(name, opts)
+}
+
+// generateRoute renders the resource routes, adding the trash endpoints for soft deleting resources
+func generateRoute(name string, opts ResourceOptions) error {
 	// Get project name from go.mod
 	projectName, err := utils.GetModuleName()
 	if err != nil {
@@ -26,6 +36,8 @@ func GenerateRoute(name string) error {
 		"ProjectName": projectName,
 		"Name":        name,
 		"LowerName":   strings.ToLower(name),
+		"SoftDelete":  opts.SoftDelete,
+		"Cached":      opts.Cached,
 	}); err != nil {
 		return err
 	}
@@ -34,6 +46,12 @@ func GenerateRoute(name string) error {
 	return updateV1Routes(name)
 }
 
+// RegisterRoutes registers an existing Setup<Name>Routes function in route/v1.go.
+// Used to repair projects where a route file exists but was never wired into SetupRoutes.
+func RegisterRoutes(resourceName string) error {
+	return updateV1Routes(resourceName)
+}
+
 // updateV1Routes automatically registers new resource routes in the main v1 router.
 // Ensures new routes are immediately available without manual configuration.
 func updateV1Routes(resourceName string) error {
@@ -3,6 +3,7 @@ package generators
 import (
 	"bytes"
 	"fmt"
+	"go/token"
 	"os"
 	"strings"
 	"text/template"
@@ -17,6 +18,16 @@ import (
 // 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
 // GenerateFieldFilter generates a specific field filter function and appends it to the filter.go file
 func GenerateFieldFilter(modelName, fieldName, fieldType string) error {
+	return generateFieldFilter(modelName, utils.Field{
+		Name:   fieldName,
+		Type:   utils.MapGoType(fieldType),
+		Column: strings.ToLower(fieldName),
+	})
+}
+
+// generateFieldFilter appends the filter functions for a field to the resource's filter.go,
+// querying its database column and importing uuid when the field is a UUID
+func generateFieldFilter(modelName string, field utils.Field) error {
 	scopeDir := fmt.Sprintf("scope/%s", strings.ToLower(modelName))
 	filterFilename := fmt.Sprintf("%s/filter.go", scopeDir)
 
@@ -35,18 +46,24 @@ func GenerateFieldFilter(modelName, fieldName, fieldType string) error {
 	}
 
 	// Check if this filter function already exists
-	if strings.Contains(string(currentContent), fmt.Sprintf("FilterBy%s", fieldName)) {
+	if strings.Contains(string(currentContent), fmt.Sprintf("FilterBy%s(", field.Name)) {
 		return nil // Filter already exists
 	}
 
+	// Parameter names must not collide with Go keywords such as "type"
+	paramName := strings.ToLower(field.Name[:1]) + field.Name[1:]
+	if token.IsKeyword(paramName) {
+		paramName += "Value"
+	}
+
 	// Generate filter function using template
 	templateData := map[string]interface{}{
 		"PackageName": strings.ToLower(modelName),
 		"ModelName":   modelName,
-		"FieldName":   fieldName,
-		"ParamName":   strings.ToLower(fieldName),
-		"ParamType":   fieldType,
-		"ColumnName":  strings.ToLower(fieldName),
+		"FieldName":   field.Name,
+		"ParamName":   paramName,
+		"ParamType":   field.Type,
+		"ColumnName":  field.Column,
 	}
 
 	filterFunc, err := renderTemplate(templates.ScopeTemplate, templateData)
@@ -55,7 +72,11 @@ func GenerateFieldFilter(modelName, fieldName, fieldType string) error {
 	}
 
 	// Append the new filter function
-	newContent := string(currentContent) + "\n" + filterFunc
+	newContent := string(currentContent)
+	if strings.HasPrefix(field.Type, "uuid.") && !strings.Contains(newContent, `"github.com/google/uuid"`) {
+		newContent = strings.Replace(newContent, "import (\n", "import (\n\t\"github.com/google/uuid\"\n", 1)
+	}
+	newContent += "\n" + filterFunc
 	return os.WriteFile(filterFilename, []byte(newContent), 0644)
 }
 
@@ -122,6 +143,79 @@ func StatusScope(status, column string) func(db *gorm.DB) *gorm.DB {
 	return nil
 }
 
+// GenerateSortScope creates scope/sort_scope.go with the shared sort parser if it is missing
+func GenerateSortScope() error {
+	filename := "scope/sort_scope.go"
+	if utils.FileExists(filename) {
+		return nil
+	}
+	return utils.WriteFile(filename, templates.SortScopeTemplate, nil)
+}
+
+// generateResourceSort writes the resource's sortable column whitelist to scope/<name>/sort.go
+func generateResourceSort(modelName string, opts ResourceOptions) error {
+	moduleName, err := utils.GetModuleName()
+	if err != nil {
+		return fmt.Errorf("failed to get module name: %w", err)
+	}
+
+	filename := fmt.Sprintf("scope/%s/sort.go", strings.ToLower(modelName))
+	return utils.WriteFile(filename, templates.ResourceSortTemplate, opts.templateData(modelName, moduleName))
+}
+
+// GenerateSelectScope creates scope/select_scope.go with the shared fields parser if it is missing
+func GenerateSelectScope() error {
+	filename := "scope/select_scope.go"
+	if utils.FileExists(filename) {
+		return nil
+	}
+	return utils.WriteFile(filename, templates.SelectScopeTemplate, nil)
+}
+
+// generateResourceFields writes the resource's selectable column whitelist to scope/<name>/fields.go
+func generateResourceFields(modelName string, opts ResourceOptions) error {
+	moduleName, err := utils.GetModuleName()
+	if err != nil {
+		return fmt.Errorf("failed to get module name: %w", err)
+	}
+
+	filename := fmt.Sprintf("scope/%s/fields.go", strings.ToLower(modelName))
+	return utils.WriteFile(filename, templates.ResourceFieldsTemplate, opts.templateData(modelName, moduleName))
+}
+
+// GenerateFilterScope creates scope/filter_scope.go with the shared filter grammar if it is missing
+func GenerateFilterScope() error {
+	if err := GenerateLikeScope(); err != nil {
+		return err
+	}
+
+	filename := "scope/filter_scope.go"
+	if utils.FileExists(filename) {
+		return nil
+	}
+	return utils.WriteFile(filename, templates.FilterScopeTemplate, nil)
+}
+
+// GenerateLikeScope creates scope/like_scope.go with the LIKE matching shared by filters and search if it is missing
+func GenerateLikeScope() error {
+	filename := "scope/like_scope.go"
+	if utils.FileExists(filename) {
+		return nil
+	}
+	return utils.WriteFile(filename, templates.LikeScopeTemplate, nil)
+}
+
+// generateResourceFilters writes the resource's filterable column whitelist to scope/<name>/filters.go
+func generateResourceFilters(modelName string, opts ResourceOptions) error {
+	moduleName, err := utils.GetModuleName()
+	if err != nil {
+		return fmt.Errorf("failed to get module name: %w", err)
+	}
+
+	filename := fmt.Sprintf("scope/%s/filters.go", strings.ToLower(modelName))
+	return utils.WriteFile(filename, templates.ResourceFiltersTemplate, opts.templateData(modelName, moduleName))
+}
+
 func GenerateScope(modelName, scopeName string) error {
 	scopeDir := fmt.Sprintf("scope/%s", strings.ToLower(modelName))
 	if err := os.MkdirAll(scopeDir, 0755); err != nil {
@@ -0,0 +1,111 @@
+package generators
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/templates"
+	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/utils"
+)
+
+// searchLanguagePattern matches PostgreSQL text search configuration names
+var searchLanguagePattern = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// SearchOptions configures full-text search over a resource's text columns
+type SearchOptions struct {
+	Columns  []string // searched columns, most important (highest ranked) first
+	Language string   // PostgreSQL text search configuration such as "english" or "simple"
+}
+
+// EnableSearch turns on full-text search over the named string fields, in the given order
+func (o *ResourceOptions) EnableSearch(names []string, language string) error {
+	// The language is written into the migration's SQL, so only plain identifiers are accepted
+	if !searchLanguagePattern.MatchString(language) {
+		return fmt.Errorf("invalid search language %q", language)
+	}
+
+	search := &SearchOptions{Language: language}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		var found *utils.Field
+		for i, field := range o.Fields {
+			if strings.EqualFold(field.Name, name) || field.Column == name || field.JsonTag == name {
+				found = &o.Fields[i]
+				break
+			}
+		}
+		if found == nil {
+			return fmt.Errorf("searchable field %q is not a field of the resource", name)
+		}
+		if found.Type != "string" {
+			return fmt.Errorf("searchable field %q must be a string or text field, not %s", name, found.Type)
+		}
+		search.Columns = append(search.Columns, found.Column)
+	}
+
+	if len(search.Columns) == 0 {
+		return fmt.Errorf("no searchable fields given")
+	}
+	o.Search = search
+	return nil
+}
+
+// GenerateSearchScope creates scope/search_scope.go with the shared full-text search if it is missing
+func GenerateSearchScope() error {
+	if err := GenerateLikeScope(); err != nil {
+		return err
+	}
+
+	filename := "scope/search_scope.go"
+	if utils.FileExists(filename) {
+		return nil
+	}
+	return utils.WriteFile(filename, templates.SearchScopeTemplate, nil)
+}
+
+// generateResourceSearch writes the resource's search configuration to scope/<name>/search.go
+func generateResourceSearch(modelName string, opts ResourceOptions) error {
+	moduleName, err := utils.GetModuleName()
+	if err != nil {
+		return fmt.Errorf("failed to get module name: %w", err)
+	}
+
+	filename := fmt.Sprintf("scope/%s/search.go", strings.ToLower(modelName))
+	return utils.WriteFile(filename, templates.ResourceSearchTemplate, opts.templateData(modelName, moduleName))
+}
+
+// generateSearchMigration writes the up and down SQL migrations that add the weighted tsvector
+// column and its GIN index, returning the created files. Columns are weighted A to D in order.
+func generateSearchMigration(opts ResourceOptions) ([]string, error) {
+	weights := []string{"A", "B", "C", "D"}
+	parts := make([]string, len(opts.Search.Columns))
+	for i, column := range opts.Search.Columns {
+		weight := weights[len(weights)-1]
+		if i < len(weights) {
+			weight = weights[i]
+		}
+		parts[i] = fmt.Sprintf("setweight(to_tsvector('%s', coalesce(%q, '')), '%s')", opts.Search.Language, column, weight)
+	}
+
+	data := map[string]interface{}{
+		"TableName":  opts.TableName,
+		"ColumnList": strings.Join(opts.Search.Columns, ", "),
+		"Expression": strings.Join(parts, " || "),
+	}
+
+	prefix := fmt.Sprintf("migrations/%s_add_%s_search", time.Now().UTC().Format("20060102150405"), opts.TableName)
+	files := []string{prefix + ".up.sql", prefix + ".down.sql"}
+	if err := utils.WriteFile(files[0], templates.SearchMigrationUpTemplate, data); err != nil {
+		return nil, err
+	}
+	if err := utils.WriteFile(files[1], templates.SearchMigrationDownTemplate, data); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
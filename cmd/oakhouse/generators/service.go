@@ -10,6 +10,11 @@ import (
 
 // GenerateServiceInterface generates a service interface file
 func GenerateServiceInterface(name string) error {
+	return generateServiceInterface(name, NewResourceOptions(name, nil))
+}
+
+// generateServiceInterface renders the service interface for a table mapping
+func generateServiceInterface(name string, opts ResourceOptions) error {
 	filename := fmt.Sprintf("service/%s_interface.go", strings.ToLower(name))
 
 	// Get module name from go.mod
@@ -18,12 +23,21 @@ func GenerateServiceInterface(name string) error {
 		return fmt.Errorf("failed to get module name: %w", err)
 	}
 
-	return utils.WriteFile(filename, templates.ServiceInterfaceTemplate, map[string]interface{}{
-		"ProjectName": moduleName,
-		"ModelName":   name,
-		"PackageName": strings.ToLower(name),
-		"VarName":     strings.ToLower(name),
-	})
+	// The bulk methods report per-item outcomes with the shared result types
+	if err := GenerateBulkUtil(); err != nil {
+		return err
+	}
+
+	return utils.WriteFile(filename, templates.ServiceInterfaceTemplate, opts.templateData(name, moduleName))
+}
+
+// GenerateBulkUtil creates util/bulk.go with the bulk result types if it is missing
+func GenerateBulkUtil() error {
+	filename := "util/bulk.go"
+	if utils.FileExists(filename) {
+		return nil
+	}
+	return utils.WriteFile(filename, templates.BulkUtilTemplate, nil)
 }
 
 // generateService generates a service layer implementation with business logic operations.
@@ -31,10 +45,12 @@ func GenerateServiceInterface(name string) error {
 // Provides clean interface between handlers and repositories following service pattern.
 // 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
 func GenerateService(name string, fields []string) error {
-	filename := fmt.Sprintf("service/%s_service.go", strings.ToLower(name))
+	return generateService(name, NewResourceOptions(name, fields))
+}
 
-	// Parse fields for template
-	parsedFields := utils.ParseFields(fields)
+// generateService renders the service implementation for a table mapping
+func generateService(name string, opts ResourceOptions) error {
+	filename := fmt.Sprintf("service/%s_service.go", strings.ToLower(name))
 
 	// Get module name from go.mod
 	moduleName, err := utils.GetModuleName()
@@ -42,13 +58,10 @@ func GenerateService(name string, fields []string) error {
 		return fmt.Errorf("failed to get module name: %w", err)
 	}
 
-	return utils.WriteFile(filename, templates.ServiceTemplate, map[string]interface{}{
-		"ProjectName": moduleName,
-		"ModelName":   name,
-		"PackageName": strings.ToLower(name),
-		"VarName":     strings.ToLower(name),
-		"Fields":      parsedFields,
-	})
+	data := opts.templateData(name, moduleName)
+	data["Tracing"] = utils.FileExists("util/tracing.go")
+	data["Events"] = utils.FileExists("util/eventbus.go")
+	return utils.WriteFile(filename, templates.ServiceTemplate, data)
 }
 
 // GenerateSimpleService generates a simplified service implementation for rapid prototyping
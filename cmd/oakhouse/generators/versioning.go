@@ -0,0 +1,47 @@
+package generators
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/templates"
+	"github.com/Oakhouse-IoT-Solutions/go-to-oakhouse/cmd/oakhouse/utils"
+)
+
+// EnableVersioning turns on optimistic locking, which adds a version column to the model
+func (o *ResourceOptions) EnableVersioning() error {
+	for _, field := range o.Fields {
+		if field.Column == "version" || strings.EqualFold(field.Name, "Version") {
+			return fmt.Errorf("field %s clashes with the version column", field.Name)
+		}
+	}
+	o.Versioned = true
+	return nil
+}
+
+// GenerateETagUtil creates util/etag.go with the optimistic locking helpers if it is missing
+func GenerateETagUtil() error {
+	filename := "util/etag.go"
+	if utils.FileExists(filename) {
+		return nil
+	}
+	return utils.WriteFile(filename, templates.ETagUtilTemplate, nil)
+}
+
+// generateVersionMigration writes the migration adding the version column of a versioned resource
+func generateVersionMigration(opts ResourceOptions) ([]string, error) {
+	data := map[string]interface{}{
+		"TableName": opts.TableName,
+	}
+
+	prefix := fmt.Sprintf("migrations/%s_add_%s_version", time.Now().UTC().Format("20060102150405"), opts.TableName)
+	files := []string{prefix + ".up.sql", prefix + ".down.sql"}
+	if err := utils.WriteFile(files[0], templates.VersionMigrationUpTemplate, data); err != nil {
+		return nil, err
+	}
+	if err := utils.WriteFile(files[1], templates.VersionMigrationDownTemplate, data); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
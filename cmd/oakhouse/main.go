@@ -38,6 +38,9 @@ Features:
 	rootCmd.AddCommand(commands.IntegrateCmd())
 	rootCmd.AddCommand(commands.ServeCmd())
 	rootCmd.AddCommand(commands.BuildCmd())
+	rootCmd.AddCommand(commands.DoctorCmd())
+	rootCmd.AddCommand(commands.UpgradeCmd())
+	rootCmd.AddCommand(commands.RoutesCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
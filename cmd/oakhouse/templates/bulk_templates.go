@@ -0,0 +1,77 @@
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package templates
+
+// BulkUtilTemplate holds the result types shared by all bulk endpoints
+const BulkUtilTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package util
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxBulkItems caps the number of items accepted by one bulk request
+const MaxBulkItems = 1000
+
+// Bulk item statuses
+const (
+	BulkCreated  = "created"
+	BulkUpdated  = "updated"
+	BulkDeleted  = "deleted"
+	BulkInvalid  = "invalid"   // failed validation, nothing was written
+	BulkNotFound = "not_found" // the id does not exist
+	BulkFailed   = "failed"    // the database rejected the item
+	BulkSkipped  = "skipped"   // valid, but not written because another item failed in atomic mode
+)
+
+// ErrBulkRolledBack is returned by atomic bulk operations when an invalid item stopped the request
+var ErrBulkRolledBack = errors.New("no items were written because at least one item failed")
+
+// BulkItemResult reports the outcome of one item of a bulk request, by its index in the request
+type BulkItemResult struct {
+	Index  int               ` + "`json:\"index\"`" + `
+	ID     interface{}       ` + "`json:\"id,omitempty\"`" + `
+	Status string            ` + "`json:\"status\"`" + `
+	Errors map[string]string ` + "`json:\"errors,omitempty\"`" + `
+	Error  string            ` + "`json:\"error,omitempty\"`" + `
+}
+
+// BulkMode reads the mode query parameter of a bulk request: "atomic" (the default) writes all
+// items or none, "partial" writes every item that succeeds and reports the others
+func BulkMode(mode string) (atomic bool, err error) {
+	switch mode {
+	case "", "atomic":
+		return true, nil
+	case "partial":
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid mode %q, expected atomic or partial", mode)
+	}
+}
+
+// SkipPending marks every result that has not failed as skipped, after an atomic bulk request
+// was rolled back
+func SkipPending(results []BulkItemResult) {
+	for i := range results {
+		switch results[i].Status {
+		case BulkInvalid, BulkNotFound, BulkFailed:
+		default:
+			results[i].Status = BulkSkipped
+		}
+	}
+}
+
+// BulkSummary counts the succeeded and failed items of a bulk request
+func BulkSummary(results []BulkItemResult) (succeeded, failed int) {
+	for _, result := range results {
+		switch result.Status {
+		case BulkCreated, BulkUpdated, BulkDeleted:
+			succeeded++
+		case BulkSkipped:
+		default:
+			failed++
+		}
+	}
+	return succeeded, failed
+}
+`
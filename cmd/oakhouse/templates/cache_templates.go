@@ -0,0 +1,286 @@
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package templates
+
+// QueryCacheUtilTemplate generates the cache-aside helpers used by cached repositories
+const QueryCacheUtilTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package util
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+	"gorm.io/gorm"
+)
+
+// QueryCache caches repository reads in Redis through a CacheManager
+type QueryCache struct {
+	cache *CacheManager
+	ttl   time.Duration
+	group singleflight.Group
+}
+
+// NewQueryCache creates a query cache whose entries expire after ttl plus up to 10% jitter
+func NewQueryCache(cache *CacheManager, ttl time.Duration) *QueryCache {
+	return &QueryCache{cache: cache, ttl: ttl}
+}
+
+// Key joins the parts of a cache key. The CacheManager adds its namespace.
+func (q *QueryCache) Key(parts ...interface{}) string {
+	key := make([]string, len(parts))
+	for i, part := range parts {
+		key[i] = fmt.Sprint(part)
+	}
+	return strings.Join(key, ":")
+}
+
+// expiration spreads expiries so entries cached together are not all reloaded at once
+func (q *QueryCache) expiration() time.Duration {
+	return q.ttl + time.Duration(rand.Int63n(int64(q.ttl)/10+1))
+}
+
+// Invalidate removes every entry cached under the given tags
+func (q *QueryCache) Invalidate(ctx context.Context, tags ...string) error {
+	return q.cache.InvalidateByTags(ctx, tags...)
+}
+
+// Remember returns the value cached under key, or loads it, caches it under tags and returns it.
+// Concurrent misses for the same key share one load, so an expired popular entry reaches the
+// database once per process. Reads in a transaction bypass the cache to see its own writes, and
+// a failing Redis only costs the cache: the value is still loaded from the database.
+func Remember[T any](ctx context.Context, q *QueryCache, key string, tags []string, load func(ctx context.Context) (T, error)) (T, error) {
+	var value T
+	if _, ok := TxFromContext(ctx); ok {
+		return load(ctx)
+	}
+
+	var data json.RawMessage
+	err := q.cache.GetCache(ctx, key, &data)
+	if err != nil && !errors.Is(err, redis.Nil) {
+		log.Printf("cache: failed to read %s: %v", key, err)
+	}
+	if err != nil {
+		shared, err, _ := q.group.Do(key, func() (interface{}, error) {
+			loaded, err := load(ctx)
+			if err != nil {
+				return nil, err
+			}
+			data, err := json.Marshal(loaded)
+			if err != nil {
+				return nil, err
+			}
+			if err := q.cache.SetCacheWithTags(ctx, key, json.RawMessage(data), q.expiration(), tags); err != nil {
+				log.Printf("cache: failed to write %s: %v", key, err)
+			}
+			return data, nil
+		})
+		if err != nil {
+			return value, err
+		}
+		data = shared.([]byte)
+	}
+
+	// Every caller decodes its own copy, so shared loads never hand out the same pointers
+	err = json.Unmarshal(data, &value)
+	return value, err
+}
+
+// ScopeKey identifies the query built by applying scopes to db. The SQL is rendered without
+// running it and hashed, so scopes producing the same query share a cache entry however
+// they were built.
+func ScopeKey(db *gorm.DB, dest interface{}, scopes ...func(*gorm.DB) *gorm.DB) string {
+	stmt := db.Session(&gorm.Session{DryRun: true, NewDB: true}).Scopes(scopes...).Find(dest).Statement
+	query := db.Dialector.Explain(stmt.SQL.String(), stmt.Vars...)
+
+	preloads := make([]string, 0, len(stmt.Preloads))
+	for name := range stmt.Preloads {
+		preloads = append(preloads, name)
+	}
+	sort.Strings(preloads)
+
+	sum := sha256.Sum256([]byte(query + "|" + strings.Join(preloads, ",")))
+	return hex.EncodeToString(sum[:16])
+}
+`
+
+// QueryCacheRouteTemplate generates the switch that enables cached repositories in the routes
+const QueryCacheRouteTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package route
+
+import (
+	"{{.ProjectName}}/util"
+)
+
+// queryCache is used by the routes of resources generated with --cache, nil when Redis is off
+var queryCache *util.QueryCache
+
+// UseQueryCache enables the cached repositories. Call it before SetupRoutes; without it the
+// routes use the plain repositories.
+func UseQueryCache(cache *util.QueryCache) {
+	queryCache = cache
+}
+`
+
+// CachedRepositoryTemplate generates the cache-aside decorator of a resource repository
+const CachedRepositoryTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"{{.ProjectName}}/model"
+	"{{.ProjectName}}/util"{{if eq .IDKind "uuid"}}
+	"github.com/google/uuid"{{end}}
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// {{.VarName}}CacheTag tags every cached {{.ModelName}} list and count
+const {{.VarName}}CacheTag = "{{.TableName}}"
+
+// {{.VarName}}RowTag tags the cached reads of one {{.ModelName}}
+func {{.VarName}}RowTag(id {{.PrimaryKey.Type}}) string {
+	return fmt.Sprintf("%s:%v", {{.VarName}}CacheTag, id)
+}
+
+// {{.VarName}}CachedPage is a cached offset page with its total
+type {{.VarName}}CachedPage struct {
+	Items []model.{{.ModelName}} ` + "`json:\"items\"`" + `
+	Total int64 ` + "`json:\"total\"`" + `
+}
+
+// {{.VarName}}CachedCursorPage is a cached keyset page with its cursors
+type {{.VarName}}CachedCursorPage struct {
+	Items []model.{{.ModelName}} ` + "`json:\"items\"`" + `
+	Page  *util.CursorPage ` + "`json:\"page\"`" + `
+}
+
+// cached{{.ModelName}}Repository is a cache-aside decorator for {{.ModelName}}Repository. FindByID,
+// FindAll, Count and the paginated finds are served from Redis, keyed by the SQL their scopes
+// produce; writes invalidate the lists and the rows they touch by tag once committed. Methods
+// not overridden here go straight to the wrapped repository.
+type cached{{.ModelName}}Repository struct {
+	{{.ModelName}}Repository
+	db    *gorm.DB
+	cache *util.QueryCache
+}
+
+// NewCached{{.ModelName}}Repository wraps repo with a cache-aside decorator
+func NewCached{{.ModelName}}Repository(repo {{.ModelName}}Repository, db *gorm.DB, cache *util.QueryCache) {{.ModelName}}Repository {
+	return &cached{{.ModelName}}Repository{ {{- .ModelName}}Repository: repo, db: db, cache: cache}
+}
+
+func (r *cached{{.ModelName}}Repository) FindAll(ctx context.Context, scopes ...func(*gorm.DB) *gorm.DB) ([]model.{{.ModelName}}, error) {
+	key := r.cache.Key({{.VarName}}CacheTag, "all", util.ScopeKey(r.db, &[]model.{{.ModelName}}{}, scopes...))
+	return util.Remember(ctx, r.cache, key, []string{ {{- .VarName}}CacheTag}, func(ctx context.Context) ([]model.{{.ModelName}}, error) {
+		return r.{{.ModelName}}Repository.FindAll(ctx, scopes...)
+	})
+}
+
+func (r *cached{{.ModelName}}Repository) FindByID(ctx context.Context, id {{.PrimaryKey.Type}}, scopes ...func(*gorm.DB) *gorm.DB) (*model.{{.ModelName}}, error) {
+	key := r.cache.Key({{.VarName}}CacheTag, "id", id, util.ScopeKey(r.db, &[]model.{{.ModelName}}{}, scopes...))
+	return util.Remember(ctx, r.cache, key, []string{ {{- .VarName}}RowTag(id)}, func(ctx context.Context) (*model.{{.ModelName}}, error) {
+		return r.{{.ModelName}}Repository.FindByID(ctx, id, scopes...)
+	})
+}
+
+func (r *cached{{.ModelName}}Repository) Count(ctx context.Context, scopes ...func(*gorm.DB) *gorm.DB) (int64, error) {
+	key := r.cache.Key({{.VarName}}CacheTag, "count", util.ScopeKey(r.db, &[]model.{{.ModelName}}{}, scopes...))
+	return util.Remember(ctx, r.cache, key, []string{ {{- .VarName}}CacheTag}, func(ctx context.Context) (int64, error) {
+		return r.{{.ModelName}}Repository.Count(ctx, scopes...)
+	})
+}
+
+func (r *cached{{.ModelName}}Repository) FindWithPagination(ctx context.Context, offset, limit int, scopes ...func(*gorm.DB) *gorm.DB) ([]model.{{.ModelName}}, int64, error) {
+	key := r.cache.Key({{.VarName}}CacheTag, "page", offset, limit, util.ScopeKey(r.db, &[]model.{{.ModelName}}{}, scopes...))
+	page, err := util.Remember(ctx, r.cache, key, []string{ {{- .VarName}}CacheTag}, func(ctx context.Context) ({{.VarName}}CachedPage, error) {
+		items, total, err := r.{{.ModelName}}Repository.FindWithPagination(ctx, offset, limit, scopes...)
+		return {{.VarName}}CachedPage{Items: items, Total: total}, err
+	})
+	return page.Items, page.Total, err
+}
+
+func (r *cached{{.ModelName}}Repository) FindWithCursor(ctx context.Context, order []clause.OrderByColumn, cursor string, limit int, scopes ...func(*gorm.DB) *gorm.DB) ([]model.{{.ModelName}}, *util.CursorPage, error) {
+	key := r.cache.Key({{.VarName}}CacheTag, "cursor", fmt.Sprint(order), cursor, limit, util.ScopeKey(r.db, &[]model.{{.ModelName}}{}, scopes...))
+	page, err := util.Remember(ctx, r.cache, key, []string{ {{- .VarName}}CacheTag}, func(ctx context.Context) ({{.VarName}}CachedCursorPage, error) {
+		items, cursorPage, err := r.{{.ModelName}}Repository.FindWithCursor(ctx, order, cursor, limit, scopes...)
+		return {{.VarName}}CachedCursorPage{Items: items, Page: cursorPage}, err
+	})
+	return page.Items, page.Page, err
+}
+
+func (r *cached{{.ModelName}}Repository) Create(ctx context.Context, {{.VarName}} *model.{{.ModelName}}) error {
+	defer r.invalidate(ctx, {{.VarName}}CacheTag)
+	return r.{{.ModelName}}Repository.Create(ctx, {{.VarName}})
+}
+
+func (r *cached{{.ModelName}}Repository) Update(ctx context.Context, {{.VarName}} *model.{{.ModelName}}) error {
+	defer r.invalidate(ctx, {{.VarName}}CacheTag, {{.VarName}}RowTag({{.VarName}}.{{.PrimaryKey.Name}}))
+	return r.{{.ModelName}}Repository.Update(ctx, {{.VarName}})
+}
+
+func (r *cached{{.ModelName}}Repository) Delete(ctx context.Context, id {{.PrimaryKey.Type}}) error {
+	defer r.invalidate(ctx, {{.VarName}}CacheTag, {{.VarName}}RowTag(id))
+	return r.{{.ModelName}}Repository.Delete(ctx, id)
+}
+{{if .Versioned}}
+func (r *cached{{.ModelName}}Repository) DeleteVersion(ctx context.Context, id {{.PrimaryKey.Type}}, version int64) error {
+	defer r.invalidate(ctx, {{.VarName}}CacheTag, {{.VarName}}RowTag(id))
+	return r.{{.ModelName}}Repository.DeleteVersion(ctx, id, version)
+}
+{{end}}
+func (r *cached{{.ModelName}}Repository) BulkCreate(ctx context.Context, {{.VarName}}s []*model.{{.ModelName}}, atomic bool) ([]error, error) {
+	defer r.invalidate(ctx, {{.VarName}}CacheTag)
+	return r.{{.ModelName}}Repository.BulkCreate(ctx, {{.VarName}}s, atomic)
+}
+
+func (r *cached{{.ModelName}}Repository) BulkUpdate(ctx context.Context, {{.VarName}}s []*model.{{.ModelName}}, atomic bool) ([]error, error) {
+	tags := []string{ {{- .VarName}}CacheTag}
+	for _, {{.VarName}} := range {{.VarName}}s {
+		tags = append(tags, {{.VarName}}RowTag({{.VarName}}.{{.PrimaryKey.Name}}))
+	}
+	defer r.invalidate(ctx, tags...)
+	return r.{{.ModelName}}Repository.BulkUpdate(ctx, {{.VarName}}s, atomic)
+}
+
+func (r *cached{{.ModelName}}Repository) BulkDelete(ctx context.Context, ids []{{.PrimaryKey.Type}}) error {
+	tags := []string{ {{- .VarName}}CacheTag}
+	for _, id := range ids {
+		tags = append(tags, {{.VarName}}RowTag(id))
+	}
+	defer r.invalidate(ctx, tags...)
+	return r.{{.ModelName}}Repository.BulkDelete(ctx, ids)
+}
+{{if .SoftDelete}}
+func (r *cached{{.ModelName}}Repository) Restore(ctx context.Context, id {{.PrimaryKey.Type}}) error {
+	defer r.invalidate(ctx, {{.VarName}}CacheTag, {{.VarName}}RowTag(id))
+	return r.{{.ModelName}}Repository.Restore(ctx, id)
+}
+
+func (r *cached{{.ModelName}}Repository) Purge(ctx context.Context, id {{.PrimaryKey.Type}}) error {
+	defer r.invalidate(ctx, {{.VarName}}CacheTag, {{.VarName}}RowTag(id))
+	return r.{{.ModelName}}Repository.Purge(ctx, id)
+}
+{{end}}
+// invalidate drops the entries under tags once the write commits. It runs after failed writes
+// too, because a partial bulk write commits the rows that succeeded.
+func (r *cached{{.ModelName}}Repository) invalidate(ctx context.Context, tags ...string) {
+	util.AfterCommit(ctx, func() {
+		if err := r.cache.Invalidate(context.WithoutCancel(ctx), tags...); err != nil {
+			log.Printf("cache: failed to invalidate %v: %v", tags, err)
+		}
+	})
+}
+`
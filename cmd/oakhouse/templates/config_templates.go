@@ -0,0 +1,467 @@
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package templates
+
+// ConfigLoaderTemplate generates the struct tag based configuration loader shared by Config and integration sections
+const ConfigLoaderTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// ProfileDir is the directory holding per-profile YAML files (development.yaml, production.yaml)
+var ProfileDir = "config"
+
+var (
+	sourcesOnce   sync.Once
+	sourcesErr    error
+	profileValues map[string]string
+	dotenvKeys    map[string]bool
+)
+
+// Profile returns the active configuration profile, taken from ENV (default: development)
+func Profile() string {
+	if profile := os.Getenv("ENV"); profile != "" {
+		return profile
+	}
+	return "development"
+}
+
+// Load binds the tagged fields of target, which must be a pointer to a struct.
+//
+// Supported tags:
+//
+//	env:"KEY"        name of the setting in the environment, .env and config/<profile>.yaml
+//	default:"value"  fallback used when no source sets the key
+//	required:"true"  loading fails when the resulting value is empty
+//	secret:"true"    in production the value must come from the environment, never .env, a profile or a default
+//
+// Sources by precedence: environment variables, .env, config/<profile>.yaml, default tag.
+// Every invalid or missing setting is reported in the returned error.
+func Load(target interface{}) error {
+	if err := loadSources(); err != nil {
+		return err
+	}
+
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config: target must be a pointer to a struct, got %T", target)
+	}
+
+	var errs []error
+	bindStruct(v.Elem(), Profile() == "production", &errs)
+	return errors.Join(errs...)
+}
+
+// loadSources reads .env into the environment and the active profile file, once per process
+func loadSources() error {
+	sourcesOnce.Do(func() {
+		dotenvKeys, sourcesErr = loadDotenv(".env")
+		if sourcesErr != nil {
+			return
+		}
+		profileValues, sourcesErr = readProfile(filepath.Join(ProfileDir, Profile()+".yaml"))
+	})
+	return sourcesErr
+}
+
+// loadDotenv copies the variables of a .env file into the environment without overriding
+// those already set, and returns the keys it set so their source can still be told apart
+func loadDotenv(path string) (map[string]bool, error) {
+	keys := map[string]bool{}
+
+	values, err := godotenv.Read(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return keys, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config: failed to read %s: %w", path, err)
+	}
+
+	for key, value := range values {
+		if _, ok := os.LookupEnv(key); ok {
+			continue
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return nil, fmt.Errorf("config: failed to set %s: %w", key, err)
+		}
+		keys[key] = true
+	}
+	return keys, nil
+}
+
+// readProfile reads a flat KEY: value YAML file; a missing file is an empty profile
+func readProfile(path string) (map[string]string, error) {
+	values := map[string]string{}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return values, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config: failed to read %s: %w", path, err)
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("config: failed to parse %s: %w", path, err)
+	}
+
+	for key, value := range raw {
+		switch v := value.(type) {
+		case nil:
+			continue
+		case []interface{}:
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprint(item)
+			}
+			values[key] = strings.Join(items, ",")
+		default:
+			values[key] = fmt.Sprint(v)
+		}
+	}
+	return values, nil
+}
+
+// lookup resolves a key and reports which source provided it
+func lookup(key, fallback string) (value, source string) {
+	if value, ok := os.LookupEnv(key); ok && value != "" {
+		if dotenvKeys[key] {
+			return value, "file"
+		}
+		return value, "env"
+	}
+	if value, ok := profileValues[key]; ok && value != "" {
+		return value, "profile"
+	}
+	if fallback != "" {
+		return fallback, "default"
+	}
+	return "", ""
+}
+
+// bindStruct sets every tagged field of v, descending into untagged nested structs
+func bindStruct(v reflect.Value, production bool, errs *[]error) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		key := field.Tag.Get("env")
+		if key == "" {
+			if field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Time{}) {
+				bindStruct(v.Field(i), production, errs)
+			}
+			continue
+		}
+
+		value, source := lookup(key, field.Tag.Get("default"))
+
+		if field.Tag.Get("secret") == "true" && production && source != "env" {
+			*errs = append(*errs, fmt.Errorf("%s is a secret and must be set in the environment in production", key))
+			continue
+		}
+		if field.Tag.Get("required") == "true" && value == "" {
+			*errs = append(*errs, fmt.Errorf("%s is required", key))
+			continue
+		}
+		if value == "" {
+			continue
+		}
+
+		if err := setField(v.Field(i), value); err != nil {
+			*errs = append(*errs, fmt.Errorf("%s: %w", key, err))
+		}
+	}
+}
+
+// setField converts a raw setting into the field's type
+func setField(field reflect.Value, value string) error {
+	if field.Type() == reflect.TypeOf(time.Duration(0)) {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q", value)
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", value)
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid unsigned integer %q", value)
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid number %q", value)
+		}
+		field.SetFloat(f)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported slice type %s", field.Type())
+		}
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
+
+// section is an integration's settings struct registered with RegisterSection
+type section struct {
+	target interface{}
+	once   sync.Once
+	err    error
+}
+
+func (s *section) load() error {
+	s.once.Do(func() {
+		s.err = Load(s.target)
+	})
+	return s.err
+}
+
+var (
+	sectionsMu sync.Mutex
+	sections   = map[string]*section{}
+)
+
+// RegisterSection registers a settings struct that is loaded with the same sources
+// and rules as Config. Integrations call it from an init function in their own file,
+// so adding settings never requires editing Config.
+func RegisterSection(name string, target interface{}) {
+	sectionsMu.Lock()
+	defer sectionsMu.Unlock()
+
+	sections[name] = &section{target: target}
+}
+
+// LoadSections loads every registered section and reports all invalid settings at once
+func LoadSections() error {
+	sectionsMu.Lock()
+	defer sectionsMu.Unlock()
+
+	var errs []error
+	for name, s := range sections {
+		if err := s.load(); err != nil {
+			errs = append(errs, fmt.Errorf("%s settings: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Section returns a registered section, loading it on first use.
+// It panics when the section is unknown or its settings are invalid.
+func Section(name string) interface{} {
+	sectionsMu.Lock()
+	s, ok := sections[name]
+	sectionsMu.Unlock()
+
+	if !ok {
+		panic(fmt.Sprintf("config: section %q is not registered", name))
+	}
+	if err := s.load(); err != nil {
+		panic(fmt.Sprintf("config: invalid %s settings: %v", name, err))
+	}
+	return s.target
+}
+`
+
+// RedisConfigTemplate generates the Redis settings section registered by the Redis integration
+const RedisConfigTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package config
+
+// RedisConfig holds the Redis connection settings
+type RedisConfig struct {
+	URL            string ` + "`env:\"REDIS_URL\"`" + ` // Redis is skipped when empty
+	Password       string ` + "`env:\"REDIS_PASSWORD\"`" + `
+	DB             int    ` + "`env:\"REDIS_DB\" default:\"0\"`" + `
+	CacheNamespace string ` + "`env:\"REDIS_CACHE_NAMESPACE\" default:\"cache\"`" + ` // Prefix of every CacheManager key
+}
+
+func init() {
+	RegisterSection("redis", &RedisConfig{})
+}
+
+// Redis returns the Redis settings
+func (c *Config) Redis() *RedisConfig {
+	return Section("redis").(*RedisConfig)
+}
+`
+
+// TracingConfigTemplate generates the tracing settings section registered by the tracing integration
+const TracingConfigTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package config
+
+// TracingConfig holds the OpenTelemetry exporter settings
+type TracingConfig struct {
+	ServiceName  string ` + "`env:\"OTEL_SERVICE_NAME\" default:\"{{.ServiceName}}\"`" + `
+	Exporter     string ` + "`env:\"OTEL_TRACES_EXPORTER\" default:\"none\"`" + `
+	OTLPEndpoint string ` + "`env:\"OTEL_EXPORTER_OTLP_ENDPOINT\" default:\"http://localhost:4318\"`" + `
+}
+
+func init() {
+	RegisterSection("tracing", &TracingConfig{})
+}
+
+// Tracing returns the tracing settings
+func (c *Config) Tracing() *TracingConfig {
+	return Section("tracing").(*TracingConfig)
+}
+`
+
+// CacheConfigTemplate generates the settings section registered by the cache integration
+const CacheConfigTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package config
+
+import "time"
+
+// CacheConfig holds the settings of the repository query cache
+type CacheConfig struct {
+	TTL time.Duration ` + "`env:\"QUERY_CACHE_TTL\" default:\"5m\"`" + ` // cached entries expire after TTL plus up to 10% jitter
+}
+
+func init() {
+	RegisterSection("cache", &CacheConfig{})
+}
+
+// Cache returns the repository query cache settings
+func (c *Config) Cache() *CacheConfig {
+	return Section("cache").(*CacheConfig)
+}
+`
+
+// IdempotencyConfigTemplate generates the settings section registered by the idempotency integration
+const IdempotencyConfigTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package config
+
+import "time"
+
+// IdempotencyConfig holds the Idempotency-Key middleware settings
+type IdempotencyConfig struct {
+	Methods  []string      ` + "`env:\"IDEMPOTENCY_METHODS\" default:\"POST\"`" + `
+	TTL      time.Duration ` + "`env:\"IDEMPOTENCY_TTL\" default:\"24h\"`" + `      // how long responses are replayed
+	LockTTL  time.Duration ` + "`env:\"IDEMPOTENCY_LOCK_TTL\" default:\"1m\"`" + ` // how long an unfinished request holds its key
+	Wait     time.Duration ` + "`env:\"IDEMPOTENCY_WAIT\" default:\"5s\"`" + `     // how long a duplicate waits for the original
+	Required bool          ` + "`env:\"IDEMPOTENCY_REQUIRED\" default:\"false\"`" + `
+}
+
+func init() {
+	RegisterSection("idempotency", &IdempotencyConfig{})
+}
+
+// Idempotency returns the Idempotency-Key middleware settings
+func (c *Config) Idempotency() *IdempotencyConfig {
+	return Section("idempotency").(*IdempotencyConfig)
+}
+`
+
+// DevelopmentProfileTemplate generates the settings used when ENV=development
+const DevelopmentProfileTemplate = `# Development profile, loaded when ENV=development (the default).
+# Keys match the environment variable names; environment variables and .env take precedence.
+DB_HOST: localhost
+DB_PORT: 5432
+DB_NAME: {{.ProjectName}}_db
+DB_SSL_MODE: disable
+PORT: 8080
+SHUTDOWN_DRAIN_DELAY: 0s
+`
+
+// ProductionProfileTemplate generates the settings used when ENV=production
+const ProductionProfileTemplate = `# Production profile, loaded when ENV=production.
+# Keys match the environment variable names; environment variables take precedence.
+# Secrets (DB_PASSWORD, JWT_SECRET) are never read from this file in production:
+# they must be set in the environment or the application refuses to start.
+DB_SSL_MODE: require
+SHUTDOWN_TIMEOUT: 30s
+SHUTDOWN_DRAIN_DELAY: 5s
+`
+
+// RateLimitConfigTemplate generates the rate limiting settings section registered by the rate limit integration
+const RateLimitConfigTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package config
+
+import "time"
+
+// RateLimitConfig holds the rate limiting middleware settings
+type RateLimitConfig struct {
+	Requests     int           ` + "`env:\"RATE_LIMIT_REQUESTS\" default:\"100\"`" + `              // requests per client and window, 0 disables the default limit
+	Window       time.Duration ` + "`env:\"RATE_LIMIT_WINDOW\" default:\"1m\"`" + `
+	Algorithm    string        ` + "`env:\"RATE_LIMIT_ALGORITHM\" default:\"sliding-window\"`" + ` // sliding-window or token-bucket
+	KeyBy        string        ` + "`env:\"RATE_LIMIT_KEY_BY\" default:\"ip\"`" + `                // ip, api-key or user
+	APIKeyHeader string        ` + "`env:\"RATE_LIMIT_API_KEY_HEADER\" default:\"X-API-Key\"`" + `
+	Routes       []string      ` + "`env:\"RATE_LIMIT_ROUTES\"`" + ` // per route group limits, e.g. /api/v1/auth=5/1m
+}
+
+func init() {
+	RegisterSection("ratelimit", &RateLimitConfig{})
+}
+
+// RateLimit returns the rate limiting middleware settings
+func (c *Config) RateLimit() *RateLimitConfig {
+	return Section("ratelimit").(*RateLimitConfig)
+}
+`
+
+// SessionConfigTemplate generates the cookie session settings section registered by the session integration
+const SessionConfigTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package config
+
+import "time"
+
+// SessionConfig holds the cookie session settings
+type SessionConfig struct {
+	Expiration        time.Duration ` + "`env:\"SESSION_EXPIRATION\" default:\"24h\"`" + `       // how long a login lasts
+	CookieName        string        ` + "`env:\"SESSION_COOKIE_NAME\" default:\"session_id\"`" + `
+	CookieDomain      string        ` + "`env:\"SESSION_COOKIE_DOMAIN\"`" + `
+	CookieSecure      bool          ` + "`env:\"SESSION_COOKIE_SECURE\" default:\"true\"`" + `    // send the cookie over HTTPS only
+	CookieSameSite    string        ` + "`env:\"SESSION_COOKIE_SAME_SITE\" default:\"Lax\"`" + ` // Lax, Strict or None
+	CookieSessionOnly bool          ` + "`env:\"SESSION_COOKIE_SESSION_ONLY\" default:\"false\"`" + ` // drop the cookie when the browser closes
+}
+
+func init() {
+	RegisterSection("session", &SessionConfig{})
+}
+
+// Session returns the cookie session settings
+func (c *Config) Session() *SessionConfig {
+	return Section("session").(*SessionConfig)
+}
+`
@@ -4,21 +4,36 @@ package templates
 // DTO templates
 const GetDtoTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
 package {{.PackageName}}
-
+{{if .Imports}}
 import (
-	"time"
-)
-
+{{range .Imports}}	"{{.}}"
+{{end}})
+{{end}}
 type Get{{.ModelName}}Dto struct {
 	Page     *int ` + "`json:\"page\" query:\"page\" validate:\"omitempty,gte=1\"`" + `
 	PageSize *int ` + "`json:\"pageSize\" query:\"pageSize\" validate:\"omitempty,gte=1,lte=200\"`" + `
-	
+	Sort     string ` + "`json:\"sort\" query:\"sort\" validate:\"omitempty\"`" + `
+
+	// Keyset pagination: send limit (and the cursor of the previous response) instead of page
+	Cursor string ` + "`json:\"cursor\" query:\"cursor\" validate:\"omitempty\"`" + `
+	Limit  *int   ` + "`json:\"limit\" query:\"limit\" validate:\"omitempty,gte=1,lte=200\"`" + `
+	Count  *bool  ` + "`json:\"count\" query:\"count\" validate:\"omitempty\"`" + `
+
+	// Sparse fieldset: comma separated response keys such as "id,name"
+	Fields string ` + "`json:\"fields\" query:\"fields\" validate:\"omitempty\"`" + `
+{{if .Search}}
+	// Full-text search query
+	Q string ` + "`json:\"q\" query:\"q\" validate:\"omitempty,max=200\"`" + `
+{{end}}
+	// Filter grammar parameters such as price[gte]=10, filled from the raw query by the handler
+	Filters map[string]string ` + "`json:\"-\" query:\"-\"`" + `
+	{{if .CreatedAt}}
 	// Date range filtering
 	StartDate *time.Time ` + "`json:\"start_date\" query:\"start_date\" validate:\"omitempty\"`" + `
 	EndDate   *time.Time ` + "`json:\"end_date\" query:\"end_date\" validate:\"omitempty\"`" + `
-	
+	{{end}}
 	// Add your filter fields here
-{{range .Fields}}	{{.Name}} {{.QueryType}} ` + "`query:\"{{.QueryTag}}\" validate:\"omitempty\"`" + `
+{{range .QueryFields}}	{{.Name}} {{.QueryType}} ` + "`query:\"{{.QueryTag}}\" validate:\"omitempty\"`" + `
 {{end}}
 }
 
@@ -36,23 +51,187 @@ func (r *Get{{.ModelName}}Dto) SetDefaults() {
 		maxPageSize := 200
 		r.PageSize = &maxPageSize
 	}
+
+	// Set default for Limit in cursor mode
+	if r.UseCursor() {
+		if r.Limit == nil || *r.Limit < 1 {
+			defaultLimit := 20
+			r.Limit = &defaultLimit
+		} else if *r.Limit > 200 {
+			maxLimit := 200
+			r.Limit = &maxLimit
+		}
+	}
+}
+
+// UseCursor reports whether the request asks for keyset pagination instead of page numbers
+func (r *Get{{.ModelName}}Dto) UseCursor() bool {
+	return r.Cursor != "" || r.Limit != nil
+}
+
+// WithCount reports whether the total count should be queried; on by default for page numbers
+// and off for cursors, overridden with count=true|false
+func (r *Get{{.ModelName}}Dto) WithCount() bool {
+	if r.Count != nil {
+		return *r.Count
+	}
+	return !r.UseCursor()
 }
 `
 
 const CreateDtoTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
 package {{.PackageName}}
-
+{{if .Imports}}
+import (
+{{range .Imports}}	"{{.}}"
+{{end}})
+{{end}}
 type Create{{.ModelName}}Dto struct {
-{{range .Fields}}	{{.Name}} {{.Type}} ` + "`json:\"{{.JsonTag}}\" validate:\"required\"`" + `
+{{if .AssignedKey}}	{{.PrimaryKey.Name}} {{.PrimaryKey.Type}} ` + "`json:\"{{.PrimaryKey.JsonTag}}\" validate:\"required\"`" + `
+{{end}}{{range .Fields}}	{{.Name}} {{if .Pointer}}*{{end}}{{.Type}} ` + "`json:\"{{.JsonTag}}\" validate:\"{{if .Nullable}}omitempty{{else}}required{{end}}\"`" + `
 {{end}}
 }
+
+// Validate checks the required fields, returning a message per invalid field or nil.
+// Numbers and booleans are not checked because their zero value is a valid input.
+func (d *Create{{.ModelName}}Dto) Validate() map[string]string {
+	errs := make(map[string]string)
+{{if .AssignedKey}}{{if eq .IDKind "uuid"}}	if d.{{.PrimaryKey.Name}} == uuid.Nil {
+{{else if eq .IDKind "int"}}	if d.{{.PrimaryKey.Name}} == 0 {
+{{else}}	if d.{{.PrimaryKey.Name}} == "" {
+{{end}}		errs["{{.PrimaryKey.JsonTag}}"] = "is required"
+	}
+{{end}}{{range .Fields}}{{if not .Nullable}}{{if eq .Type "string"}}	if d.{{.Name}} == "" {
+		errs["{{.JsonTag}}"] = "is required"
+	}
+{{else if eq .Type "time.Time"}}	if d.{{.Name}}.IsZero() {
+		errs["{{.JsonTag}}"] = "is required"
+	}
+{{else if eq .Type "uuid.UUID"}}	if d.{{.Name}} == uuid.Nil {
+		errs["{{.JsonTag}}"] = "is required"
+	}
+{{end}}{{end}}{{end}}	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// RequiredKeys are the JSON keys a full {{.ModelName}} body must set, which PUT and PATCH check
+// because a missing or null key would otherwise clear the column
+var RequiredKeys = []string{
+{{range .Fields}}{{if not .Nullable}}	"{{.JsonTag}}",
+{{end}}{{end}}}
 `
 
 const UpdateDtoTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
 package {{.PackageName}}
-
+{{if .Imports}}
+import (
+{{range .Imports}}	"{{.}}"
+{{end}})
+{{end}}
 type Update{{.ModelName}}Dto struct {
 {{range .Fields}}	{{.Name}} *{{.Type}} ` + "`json:\"{{.JsonTag}}\" validate:\"omitempty\"`" + `
+{{end}}{{if .Versioned}}	// Version is the version the update is based on, sent in the body of bulk update items;
+	// the update fails with a conflict when the row has changed since.
+	Version *int64 ` + "`json:\"version,omitempty\"`" + `
+{{end}}
+}
+
+// Validate checks that required fields are not cleared, returning a message per invalid field or nil
+func (d *Update{{.ModelName}}Dto) Validate() map[string]string {
+	errs := make(map[string]string)
+{{range .Fields}}{{if not .Nullable}}{{if eq .Type "string"}}	if d.{{.Name}} != nil && *d.{{.Name}} == "" {
+		errs["{{.JsonTag}}"] = "cannot be empty"
+	}
+{{end}}{{end}}{{end}}	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+`
+
+// ResponseDtoTemplate is the JSON shape returned for a resource, so models (and internal columns
+// such as deleted_at) are never serialized directly
+const ResponseDtoTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package {{.PackageName}}
+
+import (
+{{range .Imports}}	"{{.}}"
 {{end}}
+	"{{.ProjectName}}/model"
+)
+
+// {{.ModelName}}Response is the JSON representation of a {{.ModelName}}
+type {{.ModelName}}Response struct {
+	{{.PrimaryKey.Name}} {{.PrimaryKey.Type}} ` + "`json:\"{{.PrimaryKey.JsonTag}}\"`" + `
+{{range .Fields}}	{{.Name}} {{if .Pointer}}*{{end}}{{.Type}} ` + "`json:\"{{.JsonTag}}\"`" + `
+{{end}}{{if .CreatedAt}}	CreatedAt time.Time ` + "`json:\"created_at\"`" + `
+{{end}}{{if .UpdatedAt}}	UpdatedAt time.Time ` + "`json:\"updated_at\"`" + `
+{{end}}{{if .SoftDelete}}	DeletedAt *time.Time ` + "`json:\"deleted_at,omitempty\"`" + `
+{{end}}{{if .Versioned}}	Version int64 ` + "`json:\"version\"`" + `
+{{end}}}
+
+// New{{.ModelName}}Response maps a {{.ModelName}} model onto its response
+func New{{.ModelName}}Response(m *model.{{.ModelName}}) {{.ModelName}}Response {
+	response := {{.ModelName}}Response{
+		{{.PrimaryKey.Name}}: m.{{.PrimaryKey.Name}},
+{{range .Fields}}		{{.Name}}: m.{{.Name}},
+{{end}}{{if .CreatedAt}}		CreatedAt: m.CreatedAt,
+{{end}}{{if .UpdatedAt}}		UpdatedAt: m.UpdatedAt,
+{{end}}{{if .Versioned}}		Version: m.Version,
+{{end}}	}
+{{if .SoftDelete}}	if m.DeletedAt.Valid {
+		response.DeletedAt = &m.DeletedAt.Time
+	}
+{{end}}	return response
+}
+
+// Create{{.ModelName}}DtoFrom maps a {{.ModelName}} onto its writable fields, the document that
+// PATCH requests are applied to
+func Create{{.ModelName}}DtoFrom(m *model.{{.ModelName}}) Create{{.ModelName}}Dto {
+	return Create{{.ModelName}}Dto{
+{{if .AssignedKey}}		{{.PrimaryKey.Name}}: m.{{.PrimaryKey.Name}},
+{{end}}{{range .Fields}}		{{.Name}}: m.{{.Name}},
+{{end}}	}
+}
+
+// New{{.ModelName}}ListResponse maps a page of {{.ModelName}} models onto responses
+func New{{.ModelName}}ListResponse(models []model.{{.ModelName}}) []{{.ModelName}}Response {
+	responses := make([]{{.ModelName}}Response, 0, len(models))
+	for i := range models {
+		responses = append(responses, New{{.ModelName}}Response(&models[i]))
+	}
+	return responses
+}
+`
+
+// BulkDtoTemplate holds the request bodies of the bulk endpoints
+const BulkDtoTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package {{.PackageName}}
+{{if .Imports}}
+import (
+{{range .Imports}}	"{{.}}"
+{{end}})
+{{end}}
+// BulkCreate{{.ModelName}}Dto creates many {{.ModelName}}s in one request
+type BulkCreate{{.ModelName}}Dto struct {
+	Items []Create{{.ModelName}}Dto ` + "`json:\"items\" validate:\"required,min=1,max=1000,dive\"`" + `
+}
+
+// BulkUpdate{{.ModelName}}Item updates one {{.ModelName}} of a bulk update, identified by its primary key
+type BulkUpdate{{.ModelName}}Item struct {
+	{{.PrimaryKey.Name}} {{.PrimaryKey.Type}} ` + "`json:\"{{.PrimaryKey.JsonTag}}\" validate:\"required\"`" + `
+	Update{{.ModelName}}Dto
+}
+
+// BulkUpdate{{.ModelName}}Dto updates many {{.ModelName}}s in one request
+type BulkUpdate{{.ModelName}}Dto struct {
+	Items []BulkUpdate{{.ModelName}}Item ` + "`json:\"items\" validate:\"required,min=1,max=1000,dive\"`" + `
+}
+
+// BulkDelete{{.ModelName}}Dto deletes many {{.ModelName}}s in one request
+type BulkDelete{{.ModelName}}Dto struct {
+	IDs []{{.PrimaryKey.Type}} ` + "`json:\"ids\" validate:\"required,min=1,max=1000\"`" + `
 }
 `
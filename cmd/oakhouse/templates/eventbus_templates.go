@@ -0,0 +1,609 @@
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package templates
+
+// EventBusTemplate generates util/eventbus.go with the Redis Streams event bus
+const EventBusTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"%[1]s/adapter"
+)
+
+// Event is a change published by a service, such as "user.created"
+type Event struct {
+	ID         string // stream entry ID, unique within the event type
+	Type       string
+	Payload    json.RawMessage
+	OccurredAt time.Time
+	Attempt    int // delivery attempt, starting at 1
+}
+
+// Decode unmarshals the event payload into v
+func (e Event) Decode(v interface{}) error {
+	return json.Unmarshal(e.Payload, v)
+}
+
+// EventHandler handles one event. Returning an error or panicking makes the event be delivered
+// again, until it has been tried MaxAttempts times and is moved to the dead-letter stream.
+// Events are delivered at least once, so handlers should tolerate duplicates.
+type EventHandler func(ctx context.Context, event Event) error
+
+// EventPublisher publishes events of a type with a JSON encoded payload
+type EventPublisher interface {
+	Publish(ctx context.Context, eventType string, payload interface{}) (string, error)
+}
+
+// EventBusOptions tunes an EventBus; zero fields use the defaults
+type EventBusOptions struct {
+	MaxLen      int64         // entries kept per stream, approximately; default 10000
+	BatchSize   int64         // events read at once per subscription; default 10
+	Block       time.Duration // how long a read waits for new events, which bounds shutdown; default 2s
+	RetryAfter  time.Duration // how long a failed or unacknowledged event waits to be retried; default 30s
+	MaxAttempts int           // deliveries before an event is dead-lettered; default 5
+}
+
+// eventSubscription is a handler registered for one event type and consumer group
+type eventSubscription struct {
+	eventType string
+	group     string
+	handler   EventHandler
+}
+
+// EventBus publishes events to Redis Streams, one stream per event type, and consumes them with
+// consumer groups. Every group receives each event once; the instances running a group share its
+// events. Events that keep failing are moved to the type's dead-letter stream.
+type EventBus struct {
+	client   *redis.Client
+	opts     EventBusOptions
+	consumer string
+
+	mu            sync.Mutex
+	subscriptions []eventSubscription
+}
+
+// NewEventBus creates an event bus on the RedisAdapter's client
+func NewEventBus(redisAdapter *adapter.RedisAdapter, opts EventBusOptions) *EventBus {
+	if opts.MaxLen <= 0 {
+		opts.MaxLen = 10000
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 10
+	}
+	if opts.Block <= 0 {
+		opts.Block = 2 * time.Second
+	}
+	if opts.RetryAfter <= 0 {
+		opts.RetryAfter = 30 * time.Second
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 5
+	}
+
+	// Consumers are named per process, so entries left pending by a crashed instance are
+	// claimed by the others once they are older than RetryAfter
+	host, _ := os.Hostname()
+	return &EventBus{
+		client:   redisAdapter.GetClient(),
+		opts:     opts,
+		consumer: fmt.Sprintf("%%s-%%d", host, os.Getpid()),
+	}
+}
+
+// DeadLetterType returns the event type that events of eventType are dead-lettered to.
+// Subscribing to it processes or inspects the failed events.
+func DeadLetterType(eventType string) string {
+	return eventType + ":dead"
+}
+
+// eventStream returns the stream holding the events of a type
+func eventStream(eventType string) string {
+	return "events:" + eventType
+}
+
+// Publish appends an event to its stream and returns the event ID
+func (b *EventBus) Publish(ctx context.Context, eventType string, payload interface{}) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode %%s event: %%w", eventType, err)
+	}
+	return b.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: eventStream(eventType),
+		MaxLen: b.opts.MaxLen,
+		Approx: true,
+		Values: []interface{}{
+			"type", eventType,
+			"payload", data,
+			"occurred_at", time.Now().UTC().Format(time.RFC3339Nano),
+		},
+	}).Result()
+}
+
+// Subscribe registers handler for the events of eventType in a consumer group, e.g. the name of
+// the service reacting to them. A new group receives the events published after Run starts.
+// Subscribe before calling Run.
+func (b *EventBus) Subscribe(eventType, group string, handler EventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscriptions = append(b.subscriptions, eventSubscription{eventType: eventType, group: group, handler: handler})
+}
+
+// Run consumes the subscribed events until ctx is cancelled
+func (b *EventBus) Run(ctx context.Context) {
+	b.mu.Lock()
+	subscriptions := append([]eventSubscription(nil), b.subscriptions...)
+	b.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, sub := range subscriptions {
+		wg.Add(1)
+		go func(sub eventSubscription) {
+			defer wg.Done()
+			b.consume(ctx, sub)
+		}(sub)
+	}
+	wg.Wait()
+}
+
+// consume reads new events of one subscription, retrying stale pending ones every Block
+func (b *EventBus) consume(ctx context.Context, sub eventSubscription) {
+	stream := eventStream(sub.eventType)
+	groupReady := false
+	var lastRetry time.Time
+
+	for ctx.Err() == nil {
+		if !groupReady {
+			if err := b.createGroup(ctx, stream, sub.group); err != nil {
+				b.pause(ctx, sub, err)
+				continue
+			}
+			groupReady = true
+		}
+
+		if time.Since(lastRetry) >= b.opts.Block {
+			lastRetry = time.Now()
+			if err := b.retry(ctx, sub, stream); err != nil && ctx.Err() == nil {
+				log.Printf("⚠️ Event bus: failed to retry %%s events for %%s: %%v", sub.eventType, sub.group, err)
+			}
+		}
+
+		streams, err := b.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    sub.group,
+			Consumer: b.consumer,
+			Streams:  []string{stream, ">"},
+			Count:    b.opts.BatchSize,
+			Block:    b.opts.Block,
+		}).Result()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			// The stream or group was deleted, e.g. by FLUSHDB
+			if strings.HasPrefix(err.Error(), "NOGROUP") {
+				groupReady = false
+			}
+			b.pause(ctx, sub, err)
+			continue
+		}
+
+		for _, s := range streams {
+			for _, msg := range s.Messages {
+				b.handle(ctx, sub, stream, msg, 1)
+			}
+		}
+	}
+}
+
+// createGroup creates the consumer group, and the stream when nothing was published yet
+func (b *EventBus) createGroup(ctx context.Context, stream, group string) error {
+	err := b.client.XGroupCreateMkStream(ctx, stream, group, "$").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+	return nil
+}
+
+// retry claims events of the group that were delivered more than RetryAfter ago without being
+// acknowledged, because their handler failed or their consumer stopped, and handles them again
+func (b *EventBus) retry(ctx context.Context, sub eventSubscription, stream string) error {
+	pending, err := b.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: stream,
+		Group:  sub.group,
+		Idle:   b.opts.RetryAfter,
+		Start:  "-",
+		End:    "+",
+		Count:  b.opts.BatchSize,
+	}).Result()
+	if err != nil || len(pending) == 0 {
+		return err
+	}
+
+	ids := make([]string, len(pending))
+	attempts := make(map[string]int, len(pending))
+	for i, entry := range pending {
+		ids[i] = entry.ID
+		attempts[entry.ID] = int(entry.RetryCount) + 1
+	}
+
+	// Claiming fails for entries another consumer claimed first, so each retry runs once
+	messages, err := b.client.XClaim(ctx, &redis.XClaimArgs{
+		Stream:   stream,
+		Group:    sub.group,
+		Consumer: b.consumer,
+		MinIdle:  b.opts.RetryAfter,
+		Messages: ids,
+	}).Result()
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range messages {
+		b.handle(ctx, sub, stream, msg, attempts[msg.ID])
+	}
+	return nil
+}
+
+// handle runs the handler for one delivery, acknowledging the event when it succeeds and
+// dead-lettering it when its last attempt fails
+func (b *EventBus) handle(ctx context.Context, sub eventSubscription, stream string, msg redis.XMessage, attempt int) {
+	event := decodeEvent(msg, attempt)
+	handleErr := callEventHandler(ctx, sub.handler, event)
+
+	// Acknowledge even when shutdown started during the handler, so it does not run twice
+	ackCtx := context.WithoutCancel(ctx)
+	if handleErr == nil {
+		if err := b.client.XAck(ackCtx, stream, sub.group, msg.ID).Err(); err != nil {
+			log.Printf("⚠️ Event bus: failed to acknowledge %%s event %%s: %%v", event.Type, msg.ID, err)
+		}
+		return
+	}
+
+	if attempt < b.opts.MaxAttempts {
+		log.Printf("⚠️ Event bus: %%s failed to handle %%s event %%s (attempt %%d of %%d), retrying in %%s: %%v",
+			sub.group, event.Type, msg.ID, attempt, b.opts.MaxAttempts, b.opts.RetryAfter, handleErr)
+		return
+	}
+
+	// Move the event to the dead-letter stream and acknowledge it in one transaction
+	_, err := b.client.TxPipelined(ackCtx, func(pipe redis.Pipeliner) error {
+		pipe.XAdd(ackCtx, &redis.XAddArgs{
+			Stream: eventStream(DeadLetterType(event.Type)),
+			MaxLen: b.opts.MaxLen,
+			Approx: true,
+			Values: []interface{}{
+				"type", event.Type,
+				"payload", string(event.Payload),
+				"occurred_at", event.OccurredAt.Format(time.RFC3339Nano),
+				"event_id", msg.ID,
+				"group", sub.group,
+				"attempts", attempt,
+				"error", handleErr.Error(),
+			},
+		})
+		pipe.XAck(ackCtx, stream, sub.group, msg.ID)
+		return nil
+	})
+	if err != nil {
+		log.Printf("⚠️ Event bus: failed to dead-letter %%s event %%s: %%v", event.Type, msg.ID, err)
+		return
+	}
+	log.Printf("❌ Event bus: %%s gave up on %%s event %%s after %%d attempts: %%v", sub.group, event.Type, msg.ID, attempt, handleErr)
+}
+
+// pause logs a Redis error and waits before the subscription tries again
+func (b *EventBus) pause(ctx context.Context, sub eventSubscription, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+	log.Printf("⚠️ Event bus: failed to read %%s events for %%s: %%v", sub.eventType, sub.group, err)
+	select {
+	case <-ctx.Done():
+	case <-time.After(b.opts.Block):
+	}
+}
+
+// decodeEvent converts a stream entry into an Event
+func decodeEvent(msg redis.XMessage, attempt int) Event {
+	event := Event{ID: msg.ID, Attempt: attempt}
+	event.Type, _ = msg.Values["type"].(string)
+	if payload, ok := msg.Values["payload"].(string); ok {
+		event.Payload = json.RawMessage(payload)
+	}
+	if occurredAt, ok := msg.Values["occurred_at"].(string); ok {
+		event.OccurredAt, _ = time.Parse(time.RFC3339Nano, occurredAt)
+	}
+	return event
+}
+
+// callEventHandler runs handler, turning a panic into an error so the event is retried
+func callEventHandler(ctx context.Context, handler EventHandler, event Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %%v", r)
+		}
+	}()
+	return handler(ctx, event)
+}
+
+var (
+	eventPublisherMu sync.RWMutex
+	eventPublisher   EventPublisher
+)
+
+// SetEventPublisher sets the publisher used by PublishEvent; nil turns publishing off
+func SetEventPublisher(publisher EventPublisher) {
+	eventPublisherMu.Lock()
+	defer eventPublisherMu.Unlock()
+	eventPublisher = publisher
+}
+
+// PublishEvent publishes an event through the publisher set with SetEventPublisher once the
+// transaction in ctx commits, or right away outside one, so rolled back changes are never
+// announced. It does nothing without a publisher. Failures are logged rather than returned,
+// because the change they announce is already saved.
+func PublishEvent(ctx context.Context, eventType string, payload interface{}) {
+	AfterCommit(ctx, func() {
+		eventPublisherMu.RLock()
+		publisher := eventPublisher
+		eventPublisherMu.RUnlock()
+		if publisher == nil {
+			return
+		}
+
+		if _, err := publisher.Publish(context.WithoutCancel(ctx), eventType, payload); err != nil {
+			log.Printf("⚠️ Failed to publish %%s event: %%v", eventType, err)
+		}
+	})
+}
+`
+
+// EventBusTestTemplate generates util/eventbus_test.go, run against an in-memory Redis
+const EventBusTestTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package util
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+
+	"%[1]s/adapter"
+	"%[1]s/config"
+)
+
+// newTestEventBus returns an event bus backed by a fresh miniredis server, polling quickly
+func newTestEventBus(t *testing.T, opts EventBusOptions) *EventBus {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	cfg := &config.Config{}
+	cfg.Redis().URL = server.Addr()
+
+	redisAdapter, err := adapter.NewRedisAdapter(cfg)
+	if err != nil {
+		t.Fatalf("failed to connect to miniredis: %%v", err)
+	}
+	t.Cleanup(func() { redisAdapter.Close() })
+
+	if opts.Block == 0 {
+		opts.Block = 20 * time.Millisecond
+	}
+	return NewEventBus(redisAdapter, opts)
+}
+
+// runEventBus consumes events until the test ends
+func runEventBus(t *testing.T, bus *EventBus) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		bus.Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+// publishWhenReady publishes once the consumer groups exist, since new groups only receive
+// events published after they are created
+func publishWhenReady(t *testing.T, bus *EventBus, eventType string, groups int, payload interface{}) {
+	t.Helper()
+	ctx := context.Background()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		existing, _ := bus.client.XInfoGroups(ctx, eventStream(eventType)).Result()
+		if len(existing) >= groups {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("consumer groups for %%s were not created", eventType)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if _, err := bus.Publish(ctx, eventType, payload); err != nil {
+		t.Fatalf("Publish: %%v", err)
+	}
+}
+
+type testUser struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+func TestEventBusDeliversToEveryGroup(t *testing.T) {
+	bus := newTestEventBus(t, EventBusOptions{})
+
+	received := make(chan string, 4)
+	for _, group := range []string{"mailer", "audit"} {
+		group := group
+		bus.Subscribe("user.created", group, func(ctx context.Context, event Event) error {
+			var user testUser
+			if err := event.Decode(&user); err != nil {
+				return err
+			}
+			received <- group + ":" + user.Name
+			return nil
+		})
+	}
+	runEventBus(t, bus)
+
+	publishWhenReady(t, bus, "user.created", 2, testUser{Name: "ada"})
+
+	got := map[string]bool{}
+	for len(got) < 2 {
+		select {
+		case msg := <-received:
+			got[msg] = true
+		case <-time.After(2 * time.Second):
+			t.Fatalf("received %%v, want one delivery per group", got)
+		}
+	}
+	if !got["mailer:ada"] || !got["audit:ada"] {
+		t.Fatalf("received %%v", got)
+	}
+
+	// Handled events are acknowledged
+	time.Sleep(50 * time.Millisecond)
+	pending, err := bus.client.XPending(context.Background(), eventStream("user.created"), "mailer").Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pending.Count != 0 {
+		t.Fatalf("%%d events still pending, want 0", pending.Count)
+	}
+}
+
+func TestEventBusRetriesFailedEvents(t *testing.T) {
+	bus := newTestEventBus(t, EventBusOptions{RetryAfter: 30 * time.Millisecond, MaxAttempts: 5})
+
+	var mu sync.Mutex
+	var attempts []int
+	handled := make(chan struct{})
+	bus.Subscribe("order.placed", "billing", func(ctx context.Context, event Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts = append(attempts, event.Attempt)
+		if event.Attempt < 3 {
+			return errors.New("payment provider unavailable")
+		}
+		close(handled)
+		return nil
+	})
+	runEventBus(t, bus)
+
+	publishWhenReady(t, bus, "order.placed", 1, map[string]int{"id": 1})
+
+	select {
+	case <-handled:
+	case <-time.After(3 * time.Second):
+		t.Fatal("event was not retried until it succeeded")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(attempts) != 3 || attempts[0] != 1 || attempts[2] != 3 {
+		t.Fatalf("attempts = %%v, want [1 2 3]", attempts)
+	}
+}
+
+func TestEventBusDeadLettersAfterMaxAttempts(t *testing.T) {
+	bus := newTestEventBus(t, EventBusOptions{RetryAfter: 20 * time.Millisecond, MaxAttempts: 2})
+
+	bus.Subscribe("order.placed", "billing", func(ctx context.Context, event Event) error {
+		panic("boom")
+	})
+	runEventBus(t, bus)
+
+	publishWhenReady(t, bus, "order.placed", 1, map[string]int{"id": 7})
+
+	ctx := context.Background()
+	deadStream := eventStream(DeadLetterType("order.placed"))
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		if n, _ := bus.client.XLen(ctx, deadStream).Result(); n == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("event was not dead-lettered")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	entries, err := bus.client.XRange(ctx, deadStream, "-", "+").Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := entries[0].Values
+	if values["group"] != "billing" || values["attempts"] != "2" || values["error"] != "panic: boom" || values["payload"] != ` + "`{\"id\":7}`" + ` {
+		t.Fatalf("dead letter = %%v", values)
+	}
+
+	pending, err := bus.client.XPending(ctx, eventStream("order.placed"), "billing").Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pending.Count != 0 {
+		t.Fatalf("%%d events still pending, want 0", pending.Count)
+	}
+}
+
+func TestPublishEvent(t *testing.T) {
+	bus := newTestEventBus(t, EventBusOptions{})
+	ctx := context.Background()
+
+	// Without a publisher nothing is sent
+	SetEventPublisher(nil)
+	PublishEvent(ctx, "user.deleted", map[string]string{"id": "1"})
+	if n, _ := bus.client.XLen(ctx, eventStream("user.deleted")).Result(); n != 0 {
+		t.Fatalf("published %%d events without a publisher", n)
+	}
+
+	SetEventPublisher(bus)
+	t.Cleanup(func() { SetEventPublisher(nil) })
+	PublishEvent(ctx, "user.deleted", map[string]string{"id": "1"})
+	entries, err := bus.client.XRange(ctx, eventStream("user.deleted"), "-", "+").Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Values["payload"] != ` + "`{\"id\":\"1\"}`" + ` {
+		t.Fatalf("entries = %%v", entries)
+	}
+}
+`
+
+// EventHandlersTemplate generates cmd/events.go, where the application subscribes to events
+const EventHandlersTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package main
+
+import (
+	"{{.ProjectName}}/util"
+)
+
+// registerEventHandlers subscribes handlers to the events published by the services, such as
+// "user.created", "user.updated" and "user.deleted". Each consumer group receives every event
+// once and retries it when the handler returns an error; events that keep failing are moved to
+// util.DeadLetterType(eventType).
+//
+//	bus.Subscribe("user.created", "welcome-mailer", func(ctx context.Context, event util.Event) error {
+//		var user model.User
+//		if err := event.Decode(&user); err != nil {
+//			return err
+//		}
+//		return mailer.SendWelcome(ctx, user.Email)
+//	})
+func registerEventHandlers(bus *util.EventBus) {
+}
+`
@@ -6,11 +6,16 @@ const HandlerTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 
 package handler
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"math"
-	"net/http"
+	"net/http"{{if eq .IDKind "int"}}
+	"strconv"{{end}}
 
 	"{{.ProjectName}}/dto/{{.PackageName}}"
 	"{{.ProjectName}}/service"
+	"{{.ProjectName}}/util"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
@@ -21,7 +26,14 @@ type {{.ModelName}}Handler interface {
 	FindById(ctx *fiber.Ctx) error
 	Create(ctx *fiber.Ctx) error
 	Update(ctx *fiber.Ctx) error
+	Patch(ctx *fiber.Ctx) error
 	Delete(ctx *fiber.Ctx) error
+	BulkCreate(ctx *fiber.Ctx) error
+	BulkUpdate(ctx *fiber.Ctx) error
+	BulkDelete(ctx *fiber.Ctx) error{{if .SoftDelete}}
+	Trashed(ctx *fiber.Ctx) error
+	Restore(ctx *fiber.Ctx) error
+	Purge(ctx *fiber.Ctx) error{{end}}
 }
 
 type {{.VarName}}Handler struct {
@@ -40,12 +52,41 @@ func (h *{{.VarName}}Handler) FindAll(ctx *fiber.Ctx) error {
 	
 	// Parse query parameters
 	_ = ctx.QueryParser(&filter)
+	filter.Filters = ctx.Queries()
 	
 	// Set default values
 	filter.SetDefaults()
 	
+	// Keyset pagination when a cursor or limit is given
+	if filter.UseCursor() {
+		{{.VarName}}s, page, total, err := h.{{.VarName}}Service.FindWithCursor(ctx.UserContext(), &filter)
+		if err != nil {
+			return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
+				"requestId": uuid.New(),
+				"message":   err.Error(),
+			})
+		}
+		
+		data, err := util.ProjectFields({{.PackageName}}.New{{.ModelName}}ListResponse({{.VarName}}s), filter.Fields)
+		if err != nil {
+			return err
+		}
+		
+		response := map[string]any{
+			"requestId":  uuid.New(),
+			"data":       data,
+			"limit":      filter.Limit,
+			"nextCursor": page.NextCursor,
+			"prevCursor": page.PrevCursor,
+		}
+		if filter.WithCount() {
+			response["total"] = total
+		}
+		return ctx.Status(http.StatusOK).JSON(response)
+	}
+	
 	// Get data from service
-	{{.VarName}}s, total, err := h.{{.VarName}}Service.FindAll(ctx.Context(), &filter)
+	{{.VarName}}s, total, err := h.{{.VarName}}Service.FindAll(ctx.UserContext(), &filter)
 	if err != nil {
 		return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
 			"requestId": uuid.New(),
@@ -53,22 +94,29 @@ func (h *{{.VarName}}Handler) FindAll(ctx *fiber.Ctx) error {
 		})
 	}
 	
-	// Calculate pagination metadata
-	lastPage := math.Ceil(float64(total) / float64(*filter.PageSize))
+	// Shape the response to the requested fields
+	data, err := util.ProjectFields({{.PackageName}}.New{{.ModelName}}ListResponse({{.VarName}}s), filter.Fields)
+	if err != nil {
+		return err
+	}
 	
-	return ctx.Status(http.StatusOK).JSON(map[string]any{
-			"requestId": uuid.New(),
-			"data":      {{.VarName}}s,
-			"total":     total,
-			"page":      filter.Page,
-			"pageSize":  filter.PageSize,
-			"lastPage":  lastPage,
-		})
+	response := map[string]any{
+		"requestId": uuid.New(),
+		"data":      data,
+		"page":      filter.Page,
+		"pageSize":  filter.PageSize,
+	}
+	if filter.WithCount() {
+		// Calculate pagination metadata
+		response["total"] = total
+		response["lastPage"] = math.Ceil(float64(total) / float64(*filter.PageSize))
+	}
+	return ctx.Status(http.StatusOK).JSON(response)
 }
 
 // FindById retrieves a single {{.ModelName}} by ID
 func (h *{{.VarName}}Handler) FindById(ctx *fiber.Ctx) error {
-	id, err := uuid.Parse(ctx.Params("id"))
+	id, err := parse{{.ModelName}}ID(ctx)
 	if err != nil {
 		return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
 			"requestId": uuid.New(),
@@ -76,17 +124,23 @@ func (h *{{.VarName}}Handler) FindById(ctx *fiber.Ctx) error {
 		})
 	}
 	
-	{{.VarName}}, err := h.{{.VarName}}Service.FindById(ctx.Context(), id)
+	found, err := h.{{.VarName}}Service.FindById(ctx.UserContext(), id)
 	if err != nil {
 		return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
 			"requestId": uuid.New(),
 			"message":   "{{.ModelName}} not found",
 		})
 	}
-	
+{{if .Versioned}}	
+	// Let clients revalidate cached copies and base conditional writes on this version
+	ctx.Set(fiber.HeaderETag, util.FormatETag(found.Version))
+	if util.ETagMatches(ctx.Get(fiber.HeaderIfNoneMatch), found.Version) {
+		return ctx.SendStatus(http.StatusNotModified)
+	}
+{{end}}	
 	return ctx.Status(http.StatusOK).JSON(map[string]any{
 		"requestId": uuid.New(),
-		"{{.VarName}}": {{.VarName}},
+		"{{.VarName}}": {{.PackageName}}.New{{.ModelName}}Response(found),
 	})
 }
 
@@ -97,8 +151,16 @@ func (h *{{.VarName}}Handler) Create(ctx *fiber.Ctx) error {
 	// Parse request body
 	_ = ctx.BodyParser(&request)
 	
+	if errs := request.Validate(); errs != nil {
+		return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
+			"requestId": uuid.New(),
+			"message":   "Validation failed",
+			"errors":    errs,
+		})
+	}
+	
 	// Create via service
-	{{.VarName}}, err := h.{{.VarName}}Service.Create(ctx.Context(), &request)
+	created, err := h.{{.VarName}}Service.Create(ctx.UserContext(), &request)
 	if err != nil {
 		return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
 			"requestId": uuid.New(),
@@ -106,15 +168,17 @@ func (h *{{.VarName}}Handler) Create(ctx *fiber.Ctx) error {
 		})
 	}
 	
-	return ctx.Status(http.StatusCreated).JSON(map[string]any{
+{{if .Versioned}}	ctx.Set(fiber.HeaderETag, util.FormatETag(created.Version))
+{{end}}	return ctx.Status(http.StatusCreated).JSON(map[string]any{
 		"requestId": uuid.New(),
-		"{{.VarName}}": {{.VarName}},
+		"{{.VarName}}": {{.PackageName}}.New{{.ModelName}}Response(created),
 	})
 }
 
-// Update updates an existing {{.ModelName}}
+// Update replaces every writable field of an existing {{.ModelName}}; the body must be complete
+// and is validated like a create
 func (h *{{.VarName}}Handler) Update(ctx *fiber.Ctx) error {
-	id, err := uuid.Parse(ctx.Params("id"))
+	id, err := parse{{.ModelName}}ID(ctx)
 	if err != nil {
 		return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
 			"requestId": uuid.New(),
@@ -122,15 +186,136 @@ func (h *{{.VarName}}Handler) Update(ctx *fiber.Ctx) error {
 		})
 	}
 	
-	var request {{.PackageName}}.Update{{.ModelName}}Dto
+{{if .Versioned}}	// If-Match makes the write conditional on the version the client last read
+	ifMatch, err := util.ParseIfMatch(ctx.Get(fiber.HeaderIfMatch))
+	if err != nil {
+		return ctx.Status(http.StatusPreconditionFailed).JSON(map[string]any{
+			"requestId": uuid.New(),
+			"message":   "Invalid If-Match header",
+		})
+	}
+	
+{{end}}	var request {{.PackageName}}.Create{{.ModelName}}Dto
 	
 	// Parse request body
-	_ = ctx.BodyParser(&request)
+	if err := ctx.BodyParser(&request); err != nil {
+		return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
+			"requestId": uuid.New(),
+			"message":   "Invalid request body",
+		})
+	}
+{{if .AssignedKey}}	request.{{.PrimaryKey.Name}} = id
+{{end}}	
+	if errs := validate{{.ModelName}}Document(ctx.Body(), &request); errs != nil {
+		return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
+			"requestId": uuid.New(),
+			"message":   "Validation failed",
+			"errors":    errs,
+		})
+	}
+	
+	// Replace via service
+	err = h.{{.VarName}}Service.Replace(ctx.UserContext(), id, &request{{if .Versioned}}, ifMatch{{end}})
+	if err != nil {
+{{if .Versioned}}		if errors.Is(err, util.ErrVersionConflict) {
+			return ctx.Status(http.StatusPreconditionFailed).JSON(map[string]any{
+				"requestId": uuid.New(),
+				"message":   "{{.ModelName}} was modified by another request",
+			})
+		}
+{{end}}		return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
+			"requestId": uuid.New(),
+			"message":   "Something went wrong",
+		})
+	}
 	
-	// Update via service
-	err = h.{{.VarName}}Service.Update(ctx.Context(), id, &request)
+	return ctx.Status(http.StatusOK).JSON(map[string]any{
+		"requestId": uuid.New(),
+		"message":   "{{.ModelName}} updated successfully",
+	})
+}
+
+// Patch applies a JSON Merge Patch (application/merge-patch+json, where null clears a value)
+// or a JSON Patch (application/json-patch+json) to a {{.ModelName}}
+func (h *{{.VarName}}Handler) Patch(ctx *fiber.Ctx) error {
+	id, err := parse{{.ModelName}}ID(ctx)
 	if err != nil {
 		return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
+			"requestId": uuid.New(),
+			"message":   "Invalid id",
+		})
+	}
+	
+{{if .Versioned}}	// If-Match makes the write conditional on the version the client last read
+	ifMatch, err := util.ParseIfMatch(ctx.Get(fiber.HeaderIfMatch))
+	if err != nil {
+		return ctx.Status(http.StatusPreconditionFailed).JSON(map[string]any{
+			"requestId": uuid.New(),
+			"message":   "Invalid If-Match header",
+		})
+	}
+	
+{{end}}	found, err := h.{{.VarName}}Service.FindById(ctx.UserContext(), id)
+	if err != nil {
+		return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
+			"requestId": uuid.New(),
+			"message":   "{{.ModelName}} not found",
+		})
+	}
+{{if .Versioned}}	if !ifMatch.Matches(found.Version) {
+		return ctx.Status(http.StatusPreconditionFailed).JSON(map[string]any{
+			"requestId": uuid.New(),
+			"message":   "{{.ModelName}} was modified by another request",
+		})
+	}
+	// Save only over the version the patch was applied to
+	ifMatch = util.MatchVersion(found.Version)
+{{end}}	
+	// Apply the patch to the writable fields and validate the result like a create
+	document, err := json.Marshal({{.PackageName}}.Create{{.ModelName}}DtoFrom(found))
+	if err != nil {
+		return err
+	}
+	patched, err := util.ApplyPatch(ctx.Get(fiber.HeaderContentType), document, ctx.Body())
+	if err != nil {
+		status := http.StatusUnprocessableEntity
+		switch {
+		case errors.Is(err, util.ErrUnsupportedPatch):
+			status = http.StatusUnsupportedMediaType
+		case errors.Is(err, util.ErrPatchTestFailed):
+			status = http.StatusConflict
+		}
+		return ctx.Status(status).JSON(map[string]any{
+			"requestId": uuid.New(),
+			"message":   err.Error(),
+		})
+	}
+	
+	var request {{.PackageName}}.Create{{.ModelName}}Dto
+	if err := json.Unmarshal(patched, &request); err != nil {
+		return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
+			"requestId": uuid.New(),
+			"message":   err.Error(),
+		})
+	}
+	if errs := validate{{.ModelName}}Document(patched, &request); errs != nil {
+		return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
+			"requestId": uuid.New(),
+			"message":   "Validation failed",
+			"errors":    errs,
+		})
+	}
+	
+	// Replace via service
+	err = h.{{.VarName}}Service.Replace(ctx.UserContext(), id, &request{{if .Versioned}}, ifMatch{{end}})
+	if err != nil {
+{{if .Versioned}}		if errors.Is(err, util.ErrVersionConflict) {
+			return ctx.Status(http.StatusPreconditionFailed).JSON(map[string]any{
+				"requestId": uuid.New(),
+				"message":   "{{.ModelName}} was modified by another request",
+			})
+		}
+{{end}}		return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
 			"requestId": uuid.New(),
 			"message":   "Something went wrong",
 		})
@@ -144,7 +329,7 @@ func (h *{{.VarName}}Handler) Update(ctx *fiber.Ctx) error {
 
 // Delete deletes a {{.ModelName}} by ID
 func (h *{{.VarName}}Handler) Delete(ctx *fiber.Ctx) error {
-	id, err := uuid.Parse(ctx.Params("id"))
+	id, err := parse{{.ModelName}}ID(ctx)
 	if err != nil {
 		return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
 			"requestId": uuid.New(),
@@ -152,9 +337,24 @@ func (h *{{.VarName}}Handler) Delete(ctx *fiber.Ctx) error {
 		})
 	}
 	
+{{if .Versioned}}	// If-Match makes the write conditional on the version the client last read
+	ifMatch, err := util.ParseIfMatch(ctx.Get(fiber.HeaderIfMatch))
+	if err != nil {
+		return ctx.Status(http.StatusPreconditionFailed).JSON(map[string]any{
+			"requestId": uuid.New(),
+			"message":   "Invalid If-Match header",
+		})
+	}
+{{end}}	
 	// Delete via service
-	if err := h.{{.VarName}}Service.Delete(ctx.Context(), id); err != nil {
-		return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
+	if err := h.{{.VarName}}Service.Delete(ctx.UserContext(), id{{if .Versioned}}, ifMatch{{end}}); err != nil {
+{{if .Versioned}}		if errors.Is(err, util.ErrVersionConflict) {
+			return ctx.Status(http.StatusPreconditionFailed).JSON(map[string]any{
+				"requestId": uuid.New(),
+				"message":   "{{.ModelName}} was modified by another request",
+			})
+		}
+{{end}}		return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
 			"requestId": uuid.New(),
 			"message":   "Something went wrong",
 		})
@@ -165,6 +365,192 @@ func (h *{{.VarName}}Handler) Delete(ctx *fiber.Ctx) error {
 		"message":   "{{.ModelName}} deleted successfully",
 	})
 }
+
+// BulkCreate creates up to util.MaxBulkItems {{.ModelName}}s; ?mode=partial keeps the valid ones
+// when others fail instead of rolling back the whole request
+func (h *{{.VarName}}Handler) BulkCreate(ctx *fiber.Ctx) error {
+	atomic, err := util.BulkMode(ctx.Query("mode"))
+	if err != nil {
+		return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
+			"requestId": uuid.New(),
+			"message":   err.Error(),
+		})
+	}
+	
+	var request {{.PackageName}}.BulkCreate{{.ModelName}}Dto
+	if err := ctx.BodyParser(&request); err != nil || len(request.Items) == 0 || len(request.Items) > util.MaxBulkItems {
+		return invalid{{.ModelName}}BulkRequest(ctx)
+	}
+	
+	results, err := h.{{.VarName}}Service.BulkCreate(ctx.UserContext(), request.Items, atomic)
+	return {{.VarName}}BulkResponse(ctx, atomic, results, err)
+}
+
+// BulkUpdate applies partial updates to up to util.MaxBulkItems {{.ModelName}}s, each item naming
+// its {{.PrimaryKey.JsonTag}}
+func (h *{{.VarName}}Handler) BulkUpdate(ctx *fiber.Ctx) error {
+	atomic, err := util.BulkMode(ctx.Query("mode"))
+	if err != nil {
+		return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
+			"requestId": uuid.New(),
+			"message":   err.Error(),
+		})
+	}
+	
+	var request {{.PackageName}}.BulkUpdate{{.ModelName}}Dto
+	if err := ctx.BodyParser(&request); err != nil || len(request.Items) == 0 || len(request.Items) > util.MaxBulkItems {
+		return invalid{{.ModelName}}BulkRequest(ctx)
+	}
+	
+	results, err := h.{{.VarName}}Service.BulkUpdate(ctx.UserContext(), request.Items, atomic)
+	return {{.VarName}}BulkResponse(ctx, atomic, results, err)
+}
+
+// BulkDelete deletes up to util.MaxBulkItems {{.ModelName}}s by ID
+func (h *{{.VarName}}Handler) BulkDelete(ctx *fiber.Ctx) error {
+	atomic, err := util.BulkMode(ctx.Query("mode"))
+	if err != nil {
+		return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
+			"requestId": uuid.New(),
+			"message":   err.Error(),
+		})
+	}
+	
+	var request {{.PackageName}}.BulkDelete{{.ModelName}}Dto
+	if err := ctx.BodyParser(&request); err != nil || len(request.IDs) == 0 || len(request.IDs) > util.MaxBulkItems {
+		return invalid{{.ModelName}}BulkRequest(ctx)
+	}
+	
+	results, err := h.{{.VarName}}Service.BulkDelete(ctx.UserContext(), request.IDs, atomic)
+	return {{.VarName}}BulkResponse(ctx, atomic, results, err)
+}
+
+{{if .SoftDelete}}// Trashed retrieves soft deleted {{.ModelName}}s with optional filtering and pagination
+func (h *{{.VarName}}Handler) Trashed(ctx *fiber.Ctx) error {
+	var filter {{.PackageName}}.Get{{.ModelName}}Dto
+	
+	// Parse query parameters
+	_ = ctx.QueryParser(&filter)
+	filter.Filters = ctx.Queries()
+	
+	// Set default values
+	filter.SetDefaults()
+	
+	{{.VarName}}s, total, err := h.{{.VarName}}Service.FindTrashed(ctx.UserContext(), &filter)
+	if err != nil {
+		return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
+			"requestId": uuid.New(),
+			"message":   err.Error(),
+		})
+	}
+	
+	return ctx.Status(http.StatusOK).JSON(map[string]any{
+		"requestId": uuid.New(),
+		"data":      {{.PackageName}}.New{{.ModelName}}ListResponse({{.VarName}}s),
+		"total":     total,
+		"page":      filter.Page,
+		"pageSize":  filter.PageSize,
+		"lastPage":  math.Ceil(float64(total) / float64(*filter.PageSize)),
+	})
+}
+
+// Restore brings a soft deleted {{.ModelName}} back by ID
+func (h *{{.VarName}}Handler) Restore(ctx *fiber.Ctx) error {
+	id, err := parse{{.ModelName}}ID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
+			"requestId": uuid.New(),
+			"message":   "Invalid id",
+		})
+	}
+	
+	if err := h.{{.VarName}}Service.Restore(ctx.UserContext(), id); err != nil {
+		return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
+			"requestId": uuid.New(),
+			"message":   "{{.ModelName}} not found in trash",
+		})
+	}
+	
+	return ctx.Status(http.StatusOK).JSON(map[string]any{
+		"requestId": uuid.New(),
+		"message":   "{{.ModelName}} restored successfully",
+	})
+}
+
+// Purge permanently deletes a {{.ModelName}} by ID
+func (h *{{.VarName}}Handler) Purge(ctx *fiber.Ctx) error {
+	id, err := parse{{.ModelName}}ID(ctx)
+	if err != nil {
+		return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
+			"requestId": uuid.New(),
+			"message":   "Invalid id",
+		})
+	}
+	
+	if err := h.{{.VarName}}Service.Purge(ctx.UserContext(), id); err != nil {
+		return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
+			"requestId": uuid.New(),
+			"message":   "{{.ModelName}} not found",
+		})
+	}
+	
+	return ctx.Status(http.StatusOK).JSON(map[string]any{
+		"requestId": uuid.New(),
+		"message":   "{{.ModelName}} purged successfully",
+	})
+}
+
+{{end}}// validate{{.ModelName}}Document checks a complete {{.ModelName}} body: every required key must be
+// present and not null, and the decoded values must pass the create rules
+func validate{{.ModelName}}Document(document []byte, request *{{.PackageName}}.Create{{.ModelName}}Dto) map[string]string {
+	errs := util.MissingKeys(document, {{.PackageName}}.RequiredKeys)
+	for key, message := range request.Validate() {
+		if errs == nil {
+			errs = make(map[string]string)
+		}
+		if _, ok := errs[key]; !ok {
+			errs[key] = message
+		}
+	}
+	return errs
+}
+
+// invalid{{.ModelName}}BulkRequest rejects a bulk body that cannot be parsed or holds too few or too many items
+func invalid{{.ModelName}}BulkRequest(ctx *fiber.Ctx) error {
+	return ctx.Status(http.StatusUnprocessableEntity).JSON(map[string]any{
+		"requestId": uuid.New(),
+		"message":   fmt.Sprintf("Request must hold between 1 and %d items", util.MaxBulkItems),
+	})
+}
+
+// {{.VarName}}BulkResponse reports every item's outcome; a rolled back atomic request is a 422
+func {{.VarName}}BulkResponse(ctx *fiber.Ctx, atomic bool, results []util.BulkItemResult, err error) error {
+	mode := "atomic"
+	if !atomic {
+		mode = "partial"
+	}
+	succeeded, failed := util.BulkSummary(results)
+	response := map[string]any{
+		"requestId": uuid.New(),
+		"mode":      mode,
+		"succeeded": succeeded,
+		"failed":    failed,
+		"results":   results,
+	}
+	if err != nil {
+		response["message"] = err.Error()
+		return ctx.Status(http.StatusUnprocessableEntity).JSON(response)
+	}
+	return ctx.Status(http.StatusOK).JSON(response)
+}
+
+// parse{{.ModelName}}ID reads the {{.ModelName}} primary key from the :id route parameter
+func parse{{.ModelName}}ID(ctx *fiber.Ctx) ({{.PrimaryKey.Type}}, error) {
+{{if eq .IDKind "uuid"}}	return uuid.Parse(ctx.Params("id"))
+{{else if eq .IDKind "int"}}	id, err := strconv.ParseInt(ctx.Params("id"), 10, 64)
+	return {{.PrimaryKey.Type}}(id), err
+{{else}}	return ctx.Params("id"), nil
+{{end}}}
 `
 
 // Simple handler template
@@ -0,0 +1,150 @@
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package templates
+
+// HealthUtilTemplate generates the pluggable health checker registry
+const HealthUtilTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package util
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	HealthStatusUp   = "up"
+	HealthStatusDown = "down"
+)
+
+// HealthCheckFunc reports the health of a single dependency; a nil error means healthy
+type HealthCheckFunc func(ctx context.Context) error
+
+// HealthCheckResult is the outcome of one dependency check
+type HealthCheckResult struct {
+	Status  string ` + "`json:\"status\"`" + `
+	Latency string ` + "`json:\"latency\"`" + `
+	Error   string ` + "`json:\"error,omitempty\"`" + `
+}
+
+// HealthReport aggregates every dependency check
+type HealthReport struct {
+	Status    string                       ` + "`json:\"status\"`" + `
+	Checks    map[string]HealthCheckResult ` + "`json:\"checks\"`" + `
+	CheckedAt time.Time                    ` + "`json:\"checkedAt\"`" + `
+}
+
+type healthCheck struct {
+	name    string
+	timeout time.Duration
+	check   HealthCheckFunc
+}
+
+// HealthRegistry runs registered dependency checks concurrently with per-check
+// timeouts and caches the report so probes cannot overload dependencies
+type HealthRegistry struct {
+	mu       sync.Mutex
+	checks   []healthCheck
+	timeout  time.Duration
+	cacheTTL time.Duration
+	cached   *HealthReport
+}
+
+// NewHealthRegistry creates a registry with a default check timeout and report cache TTL
+func NewHealthRegistry(timeout, cacheTTL time.Duration) *HealthRegistry {
+	return &HealthRegistry{
+		timeout:  timeout,
+		cacheTTL: cacheTTL,
+	}
+}
+
+// Register adds a check that uses the registry's default timeout
+func (r *HealthRegistry) Register(name string, check HealthCheckFunc) {
+	r.RegisterWithTimeout(name, r.timeout, check)
+}
+
+// RegisterWithTimeout adds a check with its own timeout
+func (r *HealthRegistry) RegisterWithTimeout(name string, timeout time.Duration, check HealthCheckFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.checks = append(r.checks, healthCheck{name: name, timeout: timeout, check: check})
+	r.cached = nil
+}
+
+// Check returns the cached report while it is fresh, otherwise runs every check
+func (r *HealthRegistry) Check(ctx context.Context) HealthReport {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cached != nil && time.Since(r.cached.CheckedAt) < r.cacheTTL {
+		return *r.cached
+	}
+
+	report := HealthReport{
+		Status:    HealthStatusUp,
+		Checks:    make(map[string]HealthCheckResult, len(r.checks)),
+		CheckedAt: time.Now(),
+	}
+
+	var wg sync.WaitGroup
+	var resultsMu sync.Mutex
+	for _, hc := range r.checks {
+		wg.Add(1)
+		go func(hc healthCheck) {
+			defer wg.Done()
+			result := runHealthCheck(ctx, hc)
+
+			resultsMu.Lock()
+			defer resultsMu.Unlock()
+			report.Checks[hc.name] = result
+			if result.Status != HealthStatusUp {
+				report.Status = HealthStatusDown
+			}
+		}(hc)
+	}
+	wg.Wait()
+
+	r.cached = &report
+	return report
+}
+
+// runHealthCheck runs a single check under its timeout and measures its latency
+func runHealthCheck(ctx context.Context, hc healthCheck) HealthCheckResult {
+	checkCtx, cancel := context.WithTimeout(ctx, hc.timeout)
+	defer cancel()
+
+	start := time.Now()
+	errCh := make(chan error, 1)
+	go func() { errCh <- hc.check(checkCtx) }()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-checkCtx.Done():
+		err = checkCtx.Err()
+	}
+
+	result := HealthCheckResult{
+		Status:  HealthStatusUp,
+		Latency: time.Since(start).Round(time.Microsecond).String(),
+	}
+	if err != nil {
+		result.Status = HealthStatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// DatabaseHealthCheck pings the connection pool behind a GORM database
+func DatabaseHealthCheck(db *gorm.DB) HealthCheckFunc {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
+`
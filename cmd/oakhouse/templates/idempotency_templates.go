@@ -0,0 +1,428 @@
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package templates
+
+// IdempotencyUtilTemplate generates the Idempotency-Key store interface and its database implementation
+const IdempotencyUtilTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyRecord is the state of an Idempotency-Key: claimed by an in-flight request, or
+// completed with the response that is replayed to retries
+type IdempotencyRecord struct {
+	Fingerprint string            ` + "`json:\"fingerprint\"`" + `
+	Completed   bool              ` + "`json:\"completed\"`" + `
+	Status      int               ` + "`json:\"status,omitempty\"`" + `
+	Headers     map[string]string ` + "`json:\"headers,omitempty\"`" + `
+	Body        []byte            ` + "`json:\"body,omitempty\"`" + `
+}
+
+// IdempotencyStore persists Idempotency-Key records for the idempotency middleware
+type IdempotencyStore interface {
+	// Reserve claims key for a request with the given fingerprint for lockTTL. It returns nil
+	// when the caller now holds the key, or the existing record when another request has it.
+	Reserve(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (*IdempotencyRecord, error)
+
+	// Complete stores the response of the request holding key, replayed for ttl
+	Complete(ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration) error
+
+	// Release drops an unfinished claim so the request can be retried
+	Release(ctx context.Context, key, fingerprint string) error
+}
+
+// idempotencyKeyRow is a row of the idempotency_keys table. expires_at is the end of the
+// claim while the request is in flight, and the end of the replay window once completed.
+type idempotencyKeyRow struct {
+	Key         string    ` + "`gorm:\"column:idempotency_key;primaryKey\"`" + `
+	Fingerprint string    ` + "`gorm:\"not null\"`" + `
+	Completed   bool      ` + "`gorm:\"not null;default:false\"`" + `
+	Status      int       ` + "`gorm:\"not null;default:0\"`" + `
+	Headers     string
+	Body        []byte
+	ExpiresAt   time.Time ` + "`gorm:\"not null\"`" + `
+	CreatedAt   time.Time
+}
+
+// TableName returns the table holding Idempotency-Key records
+func (idempotencyKeyRow) TableName() string {
+	return "idempotency_keys"
+}
+
+// GormIdempotencyStore keeps Idempotency-Key records in the idempotency_keys table
+type GormIdempotencyStore struct {
+	db *gorm.DB
+}
+
+// NewGormIdempotencyStore creates an Idempotency-Key store backed by the database
+func NewGormIdempotencyStore(db *gorm.DB) *GormIdempotencyStore {
+	return &GormIdempotencyStore{db: db}
+}
+
+// Reserve inserts a claim for key. An expired key, or one whose request died without
+// releasing it, is taken over; otherwise the stored record is returned.
+func (s *GormIdempotencyStore) Reserve(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (*IdempotencyRecord, error) {
+	db := s.db.WithContext(ctx)
+	for attempt := 0; attempt < 3; attempt++ {
+		now := time.Now().UTC()
+		claim := idempotencyKeyRow{Key: key, Fingerprint: fingerprint, ExpiresAt: now.Add(lockTTL), CreatedAt: now}
+		result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&claim)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 1 {
+			return nil, nil
+		}
+
+		result = db.Model(&idempotencyKeyRow{}).
+			Where("idempotency_key = ? AND expires_at < ?", key, now).
+			Updates(map[string]interface{}{
+				"fingerprint": fingerprint,
+				"completed":   false,
+				"status":      0,
+				"headers":     "",
+				"body":        nil,
+				"expires_at":  claim.ExpiresAt,
+				"created_at":  now,
+			})
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 1 {
+			return nil, nil
+		}
+
+		var row idempotencyKeyRow
+		err := db.Where("idempotency_key = ?", key).Take(&row).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Released between the insert and the read; try to claim it again
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		record := &IdempotencyRecord{Fingerprint: row.Fingerprint, Completed: row.Completed, Status: row.Status, Body: row.Body}
+		if row.Headers != "" {
+			if err := json.Unmarshal([]byte(row.Headers), &record.Headers); err != nil {
+				return nil, err
+			}
+		}
+		return record, nil
+	}
+	return nil, fmt.Errorf("idempotency key %s is contended", key)
+}
+
+// Complete stores the response of the request holding key
+func (s *GormIdempotencyStore) Complete(ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration) error {
+	headers, err := json.Marshal(record.Headers)
+	if err != nil {
+		return err
+	}
+	return s.db.WithContext(ctx).Model(&idempotencyKeyRow{}).
+		Where("idempotency_key = ? AND fingerprint = ? AND completed = ?", key, record.Fingerprint, false).
+		Updates(map[string]interface{}{
+			"completed":  true,
+			"status":     record.Status,
+			"headers":    string(headers),
+			"body":       record.Body,
+			"expires_at": time.Now().UTC().Add(ttl),
+		}).Error
+}
+
+// Release deletes an unfinished claim
+func (s *GormIdempotencyStore) Release(ctx context.Context, key, fingerprint string) error {
+	return s.db.WithContext(ctx).
+		Where("idempotency_key = ? AND fingerprint = ? AND completed = ?", key, fingerprint, false).
+		Delete(&idempotencyKeyRow{}).Error
+}
+
+// DeleteExpired removes the records whose replay window or claim has ended
+func (s *GormIdempotencyStore) DeleteExpired(ctx context.Context) (int64, error) {
+	result := s.db.WithContext(ctx).Where("expires_at < ?", time.Now().UTC()).Delete(&idempotencyKeyRow{})
+	return result.RowsAffected, result.Error
+}
+
+// PurgeExpired calls DeleteExpired every interval until ctx is cancelled
+func (s *GormIdempotencyStore) PurgeExpired(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, _ = s.DeleteExpired(ctx)
+		}
+	}
+}
+`
+
+// IdempotencyRedisUtilTemplate generates the Redis implementation of the Idempotency-Key store
+const IdempotencyRedisUtilTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// idempotencyKeyPrefix namespaces Idempotency-Key records in Redis
+const idempotencyKeyPrefix = "idempotency:"
+
+// releaseIdempotencyKey deletes a claim only while it is still the caller's unfinished claim
+var releaseIdempotencyKey = redis.NewScript(` + "`" + `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+` + "`" + `)
+
+// RedisIdempotencyStore keeps Idempotency-Key records in Redis, expiring them with TTLs
+type RedisIdempotencyStore struct {
+	client *redis.Client
+}
+
+// NewRedisIdempotencyStore creates an Idempotency-Key store backed by Redis
+func NewRedisIdempotencyStore(client *redis.Client) *RedisIdempotencyStore {
+	return &RedisIdempotencyStore{client: client}
+}
+
+// Reserve claims key with SET NX, or returns the record already stored under it
+func (s *RedisIdempotencyStore) Reserve(ctx context.Context, key, fingerprint string, lockTTL time.Duration) (*IdempotencyRecord, error) {
+	claim, err := json.Marshal(IdempotencyRecord{Fingerprint: fingerprint})
+	if err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; attempt < 3; attempt++ {
+		claimed, err := s.client.SetNX(ctx, idempotencyKeyPrefix+key, claim, lockTTL).Result()
+		if err != nil {
+			return nil, err
+		}
+		if claimed {
+			return nil, nil
+		}
+
+		data, err := s.client.Get(ctx, idempotencyKeyPrefix+key).Bytes()
+		if errors.Is(err, redis.Nil) {
+			// Expired or released between the two commands; try to claim it again
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var record IdempotencyRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			return nil, err
+		}
+		return &record, nil
+	}
+	return nil, fmt.Errorf("idempotency key %s is contended", key)
+}
+
+// Complete replaces the claim with the response, expiring after ttl
+func (s *RedisIdempotencyStore) Complete(ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration) error {
+	completed := *record
+	completed.Completed = true
+	data, err := json.Marshal(completed)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, idempotencyKeyPrefix+key, data, ttl).Err()
+}
+
+// Release deletes an unfinished claim
+func (s *RedisIdempotencyStore) Release(ctx context.Context, key, fingerprint string) error {
+	claim, err := json.Marshal(IdempotencyRecord{Fingerprint: fingerprint})
+	if err != nil {
+		return err
+	}
+	return releaseIdempotencyKey.Run(ctx, s.client, []string{idempotencyKeyPrefix + key}, claim).Err()
+}
+`
+
+// IdempotencyMiddlewareTemplate generates the Idempotency-Key middleware
+const IdempotencyMiddlewareTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"strings"
+	"time"
+
+	"{{.ProjectName}}/config"
+	"{{.ProjectName}}/util"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header carrying the client chosen key
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotentReplayedHeader is set on responses replayed from a stored result
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	// maxIdempotencyKeyLength bounds the header so clients cannot store arbitrary data
+	maxIdempotencyKeyLength = 255
+)
+
+// idempotencyPollInterval is how often a duplicate checks whether the original finished
+const idempotencyPollInterval = 50 * time.Millisecond
+
+// replayedHeaders are the response headers stored and replayed with the body
+var replayedHeaders = []string{fiber.HeaderContentType, fiber.HeaderLocation, fiber.HeaderETag}
+
+// Idempotency makes retries of the configured methods safe. The first request with an
+// Idempotency-Key runs and its response is stored; retries with the same key and payload get
+// that response back, marked with Idempotent-Replayed. A key reused with another payload is
+// rejected with 422, and a duplicate arriving while the original is still running waits for it,
+// then gets 409 if it has not finished. 5xx responses are not stored, so they can be retried.
+func Idempotency(store util.IdempotencyStore, cfg *config.IdempotencyConfig) fiber.Handler {
+	methods := make(map[string]bool, len(cfg.Methods))
+	for _, method := range cfg.Methods {
+		methods[strings.ToUpper(method)] = true
+	}
+
+	return func(c *fiber.Ctx) error {
+		if !methods[c.Method()] {
+			return c.Next()
+		}
+
+		key := c.Get(IdempotencyKeyHeader)
+		if key == "" {
+			if cfg.Required {
+				return fiber.NewError(fiber.StatusBadRequest, IdempotencyKeyHeader+" header is required")
+			}
+			return c.Next()
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			return fiber.NewError(fiber.StatusBadRequest, IdempotencyKeyHeader+" header is too long")
+		}
+
+		ctx := c.UserContext()
+		storageKey := idempotencyStorageKey(c, key)
+		fingerprint := idempotencyFingerprint(c)
+
+		deadline := time.Now().Add(cfg.Wait)
+		for {
+			record, err := store.Reserve(ctx, storageKey, fingerprint, cfg.LockTTL)
+			if err != nil {
+				return err
+			}
+			if record == nil {
+				break
+			}
+			if record.Fingerprint != fingerprint {
+				return fiber.NewError(fiber.StatusUnprocessableEntity, IdempotencyKeyHeader+" was already used with a different request")
+			}
+			if record.Completed {
+				for name, value := range record.Headers {
+					c.Set(name, value)
+				}
+				c.Set(IdempotentReplayedHeader, "true")
+				return c.Status(record.Status).Send(record.Body)
+			}
+			if time.Now().After(deadline) {
+				return fiber.NewError(fiber.StatusConflict, "a request with this "+IdempotencyKeyHeader+" is still being processed")
+			}
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(idempotencyPollInterval):
+			}
+		}
+
+		// Release the claim unless a response was stored, including when the handler panics
+		stored := false
+		defer func() {
+			if !stored {
+				if err := store.Release(ctx, storageKey, fingerprint); err != nil {
+					log.Printf("idempotency: failed to release key: %v", err)
+				}
+			}
+		}()
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		status := c.Response().StatusCode()
+		if status >= fiber.StatusInternalServerError {
+			return nil
+		}
+
+		record := &util.IdempotencyRecord{
+			Fingerprint: fingerprint,
+			Status:      status,
+			Headers:     make(map[string]string),
+			Body:        append([]byte(nil), c.Response().Body()...),
+		}
+		for _, name := range replayedHeaders {
+			if value := c.GetRespHeader(name); value != "" {
+				record.Headers[name] = value
+			}
+		}
+
+		// The side effects already happened: keep the claim until it expires rather than
+		// letting an immediate retry run them again
+		stored = true
+		if err := store.Complete(ctx, storageKey, record, cfg.TTL); err != nil {
+			log.Printf("idempotency: failed to store response: %v", err)
+		}
+		return nil
+	}
+}
+
+// idempotencyStorageKey scopes a client key to the caller's credentials and user, so clients
+// cannot replay each other's responses
+func idempotencyStorageKey(c *fiber.Ctx, key string) string {
+	userID, _ := util.CurrentUserID(c)
+	sum := sha256.Sum256([]byte(c.Get(fiber.HeaderAuthorization) + "\n" + userID + "\n" + key))
+	return hex.EncodeToString(sum[:])
+}
+
+// idempotencyFingerprint identifies the request a key was first used with
+func idempotencyFingerprint(c *fiber.Ctx) string {
+	hash := sha256.New()
+	hash.Write([]byte(c.Method() + " " + c.OriginalURL() + "\n"))
+	hash.Write(c.Body())
+	return hex.EncodeToString(hash.Sum(nil))
+}
+`
+
+// IdempotencyMigrationUpTemplate creates the table of the database Idempotency-Key store
+const IdempotencyMigrationUpTemplate = `CREATE TABLE IF NOT EXISTS idempotency_keys (
+    idempotency_key varchar(64) PRIMARY KEY,
+    fingerprint varchar(64) NOT NULL,
+    completed boolean NOT NULL DEFAULT false,
+    status integer NOT NULL DEFAULT 0,
+    headers text,
+    body bytea,
+    expires_at timestamptz NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
+`
+
+// IdempotencyMigrationDownTemplate drops the table of the database Idempotency-Key store
+const IdempotencyMigrationDownTemplate = `DROP TABLE IF EXISTS idempotency_keys;
+`
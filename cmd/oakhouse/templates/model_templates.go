@@ -4,22 +4,22 @@ package templates
 // Model templates
 const ModelTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
 package model
-
+{{if .Imports}}
 import (
-	"time"
-	"github.com/google/uuid"
-	"gorm.io/gorm"
-)
-
+{{range .Imports}}	"{{.}}"
+{{end}})
+{{end}}
 type {{.ModelName}} struct {
-	ID        uuid.UUID      ` + "`gorm:\"type:uuid;primary_key;default:gen_random_uuid()\" json:\"id\"`" + `
-{{range .Fields}}	{{.Name}} {{.Type}} ` + "`gorm:\"{{.GormTag}}\" json:\"{{.JsonTag}}\"`" + `
-{{end}}	CreatedAt time.Time      ` + "`gorm:\"autoCreateTime\" json:\"created_at\"`" + `
-	UpdatedAt time.Time      ` + "`gorm:\"autoUpdateTime\" json:\"updated_at\"`" + `
-	DeletedAt gorm.DeletedAt ` + "`gorm:\"index\" json:\"deleted_at,omitempty\"`" + `
-}
+	{{.PrimaryKey.Name}} {{.PrimaryKey.Type}} ` + "`gorm:\"{{.PrimaryKey.GormTag}}\" json:\"{{.PrimaryKey.JsonTag}}\"`" + `
+{{range .Fields}}	{{.Name}} {{if .Pointer}}*{{end}}{{.Type}} ` + "`gorm:\"{{.GormTag}}\" json:\"{{.JsonTag}}\"`" + `
+{{end}}{{range .Relations}}	{{.Name}} *{{.Model}} ` + "`gorm:\"foreignKey:{{.ForeignKey}}\" json:\"{{.JsonTag}},omitempty\"`" + `
+{{end}}{{if .CreatedAt}}	CreatedAt time.Time ` + "`gorm:\"autoCreateTime\" json:\"created_at\"`" + `
+{{end}}{{if .UpdatedAt}}	UpdatedAt time.Time ` + "`gorm:\"autoUpdateTime\" json:\"updated_at\"`" + `
+{{end}}{{if .SoftDelete}}	DeletedAt gorm.DeletedAt ` + "`gorm:\"index\" json:\"deleted_at,omitempty\"`" + `
+{{end}}{{if .Versioned}}	Version int64 ` + "`gorm:\"not null;default:1\" json:\"version\"`" + `
+{{end}}}
 
 func ({{.ModelName}}) TableName() string {
 	return "{{.TableName}}"
 }
-`
\ No newline at end of file
+`
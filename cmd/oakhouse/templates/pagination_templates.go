@@ -0,0 +1,206 @@
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package templates
+
+// CursorUtilTemplate generates the keyset pagination helper used by FindWithCursor
+const CursorUtilTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package util
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// ErrInvalidCursor is returned when a cursor cannot be decoded or belongs to another sort order
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// CursorPage holds the cursors around a keyset page; a cursor is empty at the end of the results
+type CursorPage struct {
+	NextCursor string ` + "`json:\"nextCursor,omitempty\"`" + `
+	PrevCursor string ` + "`json:\"prevCursor,omitempty\"`" + `
+}
+
+// cursorPayload is the decoded form of an opaque cursor: the order column values of the row
+// the page starts after, the sort order they belong to and the paging direction
+type cursorPayload struct {
+	Sort     string            ` + "`json:\"s\"`" + `
+	Values   []json.RawMessage ` + "`json:\"v\"`" + `
+	Backward bool              ` + "`json:\"b,omitempty\"`" + `
+}
+
+// KeysetPaginate loads one page of rows into dest (a pointer to a slice of models) ordered by
+// columns, starting after the row encoded in cursor. Unlike OFFSET pagination the cost does not
+// grow with the page number, and no COUNT query is needed. columns must end with a unique
+// column such as the primary key, and cursor columns should be NOT NULL.
+func KeysetPaginate(db *gorm.DB, dest interface{}, columns []clause.OrderByColumn, cursor string, limit int) (*CursorPage, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(dest); err != nil {
+		return nil, err
+	}
+	fields := make([]*schema.Field, len(columns))
+	for i, column := range columns {
+		if fields[i] = stmt.Schema.LookUpField(column.Column.Name); fields[i] == nil {
+			return nil, fmt.Errorf("unknown cursor column %s", column.Column.Name)
+		}
+	}
+	sortKey := cursorSortKey(columns)
+
+	// Continue after the cursor row, walking backwards by reversing the order
+	query := db
+	backward := false
+	if cursor != "" {
+		payload, err := decodeCursor(cursor)
+		if err != nil || payload.Sort != sortKey || len(payload.Values) != len(columns) {
+			return nil, ErrInvalidCursor
+		}
+		backward = payload.Backward
+
+		values := make([]interface{}, len(columns))
+		for i, field := range fields {
+			value := reflect.New(field.FieldType)
+			if err := json.Unmarshal(payload.Values[i], value.Interface()); err != nil {
+				return nil, ErrInvalidCursor
+			}
+			values[i] = value.Elem().Interface()
+		}
+		query = query.Where(keysetCondition(columns, values, backward))
+	}
+
+	order := make([]clause.OrderByColumn, len(columns))
+	for i, column := range columns {
+		order[i] = column
+		order[i].Desc = column.Desc != backward
+	}
+
+	// One extra row tells whether another page follows
+	if err := query.Clauses(clause.OrderBy{Columns: order}).Limit(limit + 1).Find(dest).Error; err != nil {
+		return nil, err
+	}
+
+	rows := reflect.ValueOf(dest).Elem()
+	hasMore := rows.Len() > limit
+	if hasMore {
+		rows.Set(rows.Slice(0, limit))
+	}
+	if backward {
+		for i, j := 0, rows.Len()-1; i < j; i, j = i+1, j-1 {
+			first, last := rows.Index(i).Interface(), rows.Index(j).Interface()
+			rows.Index(i).Set(reflect.ValueOf(last))
+			rows.Index(j).Set(reflect.ValueOf(first))
+		}
+	}
+
+	page := &CursorPage{}
+	if rows.Len() == 0 {
+		return page, nil
+	}
+	var err error
+	first, last := rows.Index(0), rows.Index(rows.Len()-1)
+	if (!backward && hasMore) || (backward && cursor != "") {
+		if page.NextCursor, err = encodeCursor(db, fields, sortKey, last, false); err != nil {
+			return nil, err
+		}
+	}
+	if (backward && hasMore) || (!backward && cursor != "") {
+		if page.PrevCursor, err = encodeCursor(db, fields, sortKey, first, true); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+// keysetCondition selects the rows after (or before) the cursor values. A single row-value
+// comparison is used when all columns share a direction so the index on them can be used;
+// mixed directions are expanded into (a > x) OR (a = x AND b < y) ...
+func keysetCondition(columns []clause.OrderByColumn, values []interface{}, backward bool) clause.Expression {
+	after := func(column clause.OrderByColumn) bool {
+		return column.Desc == backward
+	}
+
+	uniform := true
+	for _, column := range columns[1:] {
+		if column.Desc != columns[0].Desc {
+			uniform = false
+		}
+	}
+	if uniform {
+		operator := "<"
+		if after(columns[0]) {
+			operator = ">"
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(columns)), ",")
+		vars := make([]interface{}, 0, len(columns)*2)
+		for _, column := range columns {
+			vars = append(vars, column.Column)
+		}
+		vars = append(vars, values...)
+		return clause.Expr{SQL: fmt.Sprintf("(%s) %s (%s)", placeholders, operator, placeholders), Vars: vars}
+	}
+
+	var branches []clause.Expression
+	for i, column := range columns {
+		var conditions []clause.Expression
+		for j := 0; j < i; j++ {
+			conditions = append(conditions, clause.Eq{Column: columns[j].Column, Value: values[j]})
+		}
+		if after(column) {
+			conditions = append(conditions, clause.Gt{Column: column.Column, Value: values[i]})
+		} else {
+			conditions = append(conditions, clause.Lt{Column: column.Column, Value: values[i]})
+		}
+		branches = append(branches, clause.And(conditions...))
+	}
+	return clause.Or(branches...)
+}
+
+// cursorSortKey identifies a sort order so cursors cannot be replayed against another one
+func cursorSortKey(columns []clause.OrderByColumn) string {
+	parts := make([]string, len(columns))
+	for i, column := range columns {
+		parts[i] = column.Column.Name
+		if column.Desc {
+			parts[i] = "-" + parts[i]
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// encodeCursor captures the order column values of a row as an opaque URL-safe cursor
+func encodeCursor(db *gorm.DB, fields []*schema.Field, sortKey string, row reflect.Value, backward bool) (string, error) {
+	payload := cursorPayload{Sort: sortKey, Backward: backward}
+	for _, field := range fields {
+		value, _ := field.ValueOf(db.Statement.Context, row)
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		payload.Values = append(payload.Values, raw)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeCursor reverses encodeCursor
+func decodeCursor(cursor string) (*cursorPayload, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+	var payload cursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, err
+	}
+	return &payload, nil
+}
+`
@@ -0,0 +1,357 @@
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package templates
+
+// PatchUtilTemplate applies JSON Merge Patch (RFC 7396) and JSON Patch (RFC 6902) documents
+const PatchUtilTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Patch media types accepted by PATCH endpoints
+const (
+	MergePatchContentType = "application/merge-patch+json"
+	JSONPatchContentType  = "application/json-patch+json"
+)
+
+var (
+	// ErrUnsupportedPatch is returned for a PATCH body in a media type that is not a patch format
+	ErrUnsupportedPatch = errors.New("unsupported patch media type, use " + MergePatchContentType + " or " + JSONPatchContentType)
+
+	// ErrInvalidPatch is returned for a malformed patch or one that cannot be applied
+	ErrInvalidPatch = errors.New("invalid patch")
+
+	// ErrPatchTestFailed is returned when a JSON Patch "test" operation does not match
+	ErrPatchTestFailed = errors.New("patch test failed")
+)
+
+// ApplyPatch applies a PATCH body to a JSON document according to its Content-Type.
+// Plain application/json is treated as a merge patch.
+func ApplyPatch(contentType string, original, patch []byte) ([]byte, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case MergePatchContentType, "application/json":
+		return MergePatch(original, patch)
+	case JSONPatchContentType:
+		return JSONPatch(original, patch)
+	default:
+		return nil, ErrUnsupportedPatch
+	}
+}
+
+// MergePatch applies a JSON Merge Patch: members of the patch replace those of the document,
+// objects are merged recursively and null removes a member
+func MergePatch(original, patch []byte) ([]byte, error) {
+	var doc, changes interface{}
+	if err := json.Unmarshal(original, &doc); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(patch, &changes); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return json.Marshal(mergeValue(doc, changes))
+}
+
+// mergeValue merges a patch value into a document value as defined by RFC 7396
+func mergeValue(target, patch interface{}) interface{} {
+	changes, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	object, ok := target.(map[string]interface{})
+	if !ok {
+		object = map[string]interface{}{}
+	}
+	for key, value := range changes {
+		if value == nil {
+			delete(object, key)
+			continue
+		}
+		object[key] = mergeValue(object[key], value)
+	}
+	return object
+}
+
+// patchOperation is one operation of a JSON Patch document
+type patchOperation struct {
+	Op    string          ` + "`json:\"op\"`" + `
+	Path  string          ` + "`json:\"path\"`" + `
+	From  string          ` + "`json:\"from\"`" + `
+	Value json.RawMessage ` + "`json:\"value\"`" + `
+}
+
+// JSONPatch applies the add, remove, replace, move, copy and test operations of a JSON Patch
+// in order. The patch is atomic: when any operation fails only the error is returned.
+func JSONPatch(original, patch []byte) ([]byte, error) {
+	var operations []patchOperation
+	if err := json.Unmarshal(patch, &operations); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	var doc interface{}
+	if err := json.Unmarshal(original, &doc); err != nil {
+		return nil, err
+	}
+
+	for i, operation := range operations {
+		var err error
+		if doc, err = applyOperation(doc, operation); err != nil {
+			return nil, fmt.Errorf("operation %d (%s %s): %w", i, operation.Op, operation.Path, err)
+		}
+	}
+	return json.Marshal(doc)
+}
+
+// applyOperation applies one JSON Patch operation, returning the updated document
+func applyOperation(doc interface{}, operation patchOperation) (interface{}, error) {
+	path, err := parsePointer(operation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation.Op {
+	case "add", "replace", "test":
+		var value interface{}
+		if len(operation.Value) == 0 {
+			return nil, fmt.Errorf("%w: missing value", ErrInvalidPatch)
+		}
+		if err := json.Unmarshal(operation.Value, &value); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+		}
+		switch operation.Op {
+		case "add":
+			return addValue(doc, path, value)
+		case "replace":
+			if len(path) == 0 {
+				return value, nil
+			}
+			if doc, _, err = removeValue(doc, path); err != nil {
+				return nil, err
+			}
+			return addValue(doc, path, value)
+		default:
+			current, err := getValue(doc, path)
+			if err != nil {
+				return nil, err
+			}
+			if !reflect.DeepEqual(current, value) {
+				return nil, ErrPatchTestFailed
+			}
+			return doc, nil
+		}
+	case "remove":
+		doc, _, err = removeValue(doc, path)
+		return doc, err
+	case "move", "copy":
+		from, err := parsePointer(operation.From)
+		if err != nil {
+			return nil, err
+		}
+		var value interface{}
+		if operation.Op == "move" {
+			if isPrefix(from, path) && len(from) < len(path) {
+				return nil, fmt.Errorf("%w: cannot move a value into itself", ErrInvalidPatch)
+			}
+			doc, value, err = removeValue(doc, from)
+		} else {
+			value, err = getValue(doc, from)
+			if err == nil {
+				value, err = copyValue(value)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		return addValue(doc, path, value)
+	default:
+		return nil, fmt.Errorf("%w: unknown operation %q", ErrInvalidPatch, operation.Op)
+	}
+}
+
+// parsePointer splits a JSON Pointer (RFC 6901) into unescaped reference tokens
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: path %q must start with /", ErrInvalidPatch, pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// isPrefix reports whether prefix is an ancestor of (or equal to) path
+func isPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// arrayIndex parses an array reference token; appending allows the index one past the end
+func arrayIndex(token string, length int, appending bool) (int, error) {
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || index > length || (index == length && !appending) || (token != "0" && strings.HasPrefix(token, "0")) {
+		return 0, fmt.Errorf("%w: invalid array index %q", ErrInvalidPatch, token)
+	}
+	return index, nil
+}
+
+// getValue returns the value at a path
+func getValue(doc interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch node := doc.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: path not found", ErrInvalidPatch)
+			}
+			doc = value
+		case []interface{}:
+			index, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			doc = node[index]
+		default:
+			return nil, fmt.Errorf("%w: path not found", ErrInvalidPatch)
+		}
+	}
+	return doc, nil
+}
+
+// updateParent calls fn with the container holding the last token of path and stores the
+// container it returns, which arrays need because inserting or removing reallocates them
+func updateParent(doc interface{}, path []string, fn func(parent interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if len(path) == 1 {
+		return fn(doc, path[0])
+	}
+	switch node := doc.(type) {
+	case map[string]interface{}:
+		child, ok := node[path[0]]
+		if !ok {
+			return nil, fmt.Errorf("%w: path not found", ErrInvalidPatch)
+		}
+		updated, err := updateParent(child, path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		node[path[0]] = updated
+		return node, nil
+	case []interface{}:
+		index, err := arrayIndex(path[0], len(node), false)
+		if err != nil {
+			return nil, err
+		}
+		updated, err := updateParent(node[index], path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		node[index] = updated
+		return node, nil
+	default:
+		return nil, fmt.Errorf("%w: path not found", ErrInvalidPatch)
+	}
+}
+
+// addValue sets an object member or inserts an array element ("-" appends)
+func addValue(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return updateParent(doc, path, func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			node[token] = value
+			return node, nil
+		case []interface{}:
+			index := len(node)
+			if token != "-" {
+				var err error
+				if index, err = arrayIndex(token, len(node), true); err != nil {
+					return nil, err
+				}
+			}
+			node = append(node, nil)
+			copy(node[index+1:], node[index:])
+			node[index] = value
+			return node, nil
+		default:
+			return nil, fmt.Errorf("%w: path not found", ErrInvalidPatch)
+		}
+	})
+}
+
+// removeValue deletes an object member or array element, returning it
+func removeValue(doc interface{}, path []string) (interface{}, interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil, fmt.Errorf("%w: cannot remove the whole document", ErrInvalidPatch)
+	}
+	var removed interface{}
+	doc, err := updateParent(doc, path, func(parent interface{}, token string) (interface{}, error) {
+		switch node := parent.(type) {
+		case map[string]interface{}:
+			value, ok := node[token]
+			if !ok {
+				return nil, fmt.Errorf("%w: path not found", ErrInvalidPatch)
+			}
+			removed = value
+			delete(node, token)
+			return node, nil
+		case []interface{}:
+			index, err := arrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			removed = node[index]
+			return append(node[:index], node[index+1:]...), nil
+		default:
+			return nil, fmt.Errorf("%w: path not found", ErrInvalidPatch)
+		}
+	})
+	return doc, removed, err
+}
+
+// copyValue deep copies a decoded JSON value so a copied subtree is not shared
+func copyValue(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var copied interface{}
+	err = json.Unmarshal(data, &copied)
+	return copied, err
+}
+
+// MissingKeys reports the keys a full JSON object representation lacks or sets to null, as
+// validation errors keyed like DTO Validate results
+func MissingKeys(doc []byte, keys []string) map[string]string {
+	var object map[string]json.RawMessage
+	_ = json.Unmarshal(doc, &object)
+
+	errs := make(map[string]string)
+	for _, key := range keys {
+		if value, ok := object[key]; !ok || string(value) == "null" {
+			errs[key] = "is required"
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+`
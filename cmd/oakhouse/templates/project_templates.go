@@ -10,6 +10,7 @@ require (
 	github.com/gofiber/fiber/v2 v2.52.0
 	github.com/google/uuid v1.5.0
 	github.com/joho/godotenv v1.4.0
+	gopkg.in/yaml.v3 v3.0.1
 	gorm.io/driver/postgres v1.5.4
 	gorm.io/gorm v1.25.5
 )
@@ -23,6 +24,7 @@ require (
 	github.com/jinzhu/inflection v1.0.0 // indirect
 	github.com/jinzhu/now v1.1.5 // indirect
 	github.com/klauspost/compress v1.17.0 // indirect
+	github.com/kr/pretty v0.3.1 // indirect
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
 	github.com/mattn/go-runewidth v0.0.15 // indirect
@@ -36,6 +38,12 @@ require (
 )
 `
 
+// ProjectManifestTemplate records the CLI version a project was generated with, read by 'oakhouse upgrade'
+const ProjectManifestTemplate = `{
+  "version": "{{.Version}}"
+}
+`
+
 const EnvExampleTemplate = `# Database Configuration
 DB_HOST=localhost
 DB_PORT=5432
@@ -47,14 +55,21 @@ DB_SSL_MODE=disable
 # Server Configuration
 PORT=8080
 ENV=development
+SHUTDOWN_TIMEOUT=15s
+SHUTDOWN_DRAIN_DELAY=0s
+
+# Health Check Configuration
+HEALTH_CHECK_TIMEOUT=2s
+HEALTH_CACHE_TTL=5s
 
 # JWT Configuration
-JWT_SECRET=your-secret-key-here
+# Required in production, where secrets must come from the environment
+JWT_SECRET=
 JWT_EXPIRES_IN=24h
 
 # CORS Configuration
 CORS_ALLOWED_ORIGINS=*
-CORS_ALLOWED_METHODS=GET,POST,PUT,DELETE,OPTIONS
+CORS_ALLOWED_METHODS=GET,POST,PUT,PATCH,DELETE,OPTIONS
 CORS_ALLOWED_HEADERS=*
 `
 
@@ -72,7 +87,7 @@ RUN apk --no-cache add ca-certificates
 WORKDIR /root/
 
 COPY --from=builder /app/main .
-COPY --from=builder /app/.env.example .env
+COPY --from=builder /app/config/*.yaml ./config/
 
 EXPOSE 8080
 
@@ -127,18 +142,15 @@ import (
 
 	"{{.ProjectName}}/config"
 	"{{.ProjectName}}/adapter"
-	"github.com/joho/godotenv"
 )
 
 func main() {
-	// Load environment variables
-	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found")
+	// Load configuration from the environment, .env and config/<profile>.yaml
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	// Load configuration
-	cfg := config.LoadConfig()
-
 	// Initialize database
 	db, err := adapter.InitializeDatabase(cfg)
 	if err != nil {
@@ -157,22 +169,38 @@ const AppServerTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
 
 	"{{.ProjectName}}/config"
 	"{{.ProjectName}}/route"
 	"{{.ProjectName}}/middleware"
+	"{{.ProjectName}}/util"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"gorm.io/gorm"
 )
 
+// Hook is a lifecycle callback run when the server starts or stops
+type Hook func(ctx context.Context) error
+
 type AppServer struct {
-	app *fiber.App
-	cfg *config.Config
-	db  *gorm.DB
+	app     *fiber.App
+	cfg     *config.Config
+	db      *gorm.DB
+	health  *util.HealthRegistry
+	ready   atomic.Bool
+	onStart []Hook
+	onStop  []Hook
 }
 
 func NewAppServer(cfg *config.Config, db *gorm.DB) *AppServer {
@@ -192,32 +220,197 @@ func NewAppServer(cfg *config.Config, db *gorm.DB) *AppServer {
 	app.Use(logger.New())
 	app.Use(cors.New(cors.Config{
 		AllowOrigins: "*",
-		AllowMethods: "GET,POST,PUT,DELETE,OPTIONS",
+		AllowMethods: "GET,POST,PUT,PATCH,DELETE,OPTIONS",
 		AllowHeaders: "*",
 	}))
 
 	// Custom middleware
 	app.Use(middleware.AuthMiddleware())
 
-	return &AppServer{
+	server := &AppServer{
 		app: app,
 		cfg: cfg,
 		db:  db,
+		health: util.NewHealthRegistry(cfg.HealthCheckTimeout, cfg.HealthCacheTTL),
+	}
+
+	// Dependency checks reported by /health/ready
+	server.health.Register("database", util.DatabaseHealthCheck(db))
+
+	// Stop hooks run in reverse order, so the database registered first is closed last
+	server.OnStop(func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.Close()
+	})
+
+	return server
+}
+
+// OnStart registers a hook run before the server starts accepting requests
+func (s *AppServer) OnStart(hook Hook) {
+	s.onStart = append(s.onStart, hook)
+}
+
+// OnStop registers a hook run after in-flight requests have drained.
+// Hooks run in reverse registration order.
+func (s *AppServer) OnStop(hook Hook) {
+	s.onStop = append(s.onStop, hook)
+}
+
+// Background runs worker for the lifetime of the server.
+// Its context is cancelled on shutdown and the server waits for it to return.
+func (s *AppServer) Background(worker func(ctx context.Context)) {
+	var wg sync.WaitGroup
+	workerCtx, cancel := context.WithCancel(context.Background())
+
+	s.OnStart(func(context.Context) error {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			worker(workerCtx)
+		}()
+		return nil
+	})
+
+	s.OnStop(func(ctx context.Context) error {
+		cancel()
+		done := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// Ready reports whether the server is accepting traffic; it turns false while draining
+func (s *AppServer) Ready() bool {
+	return s.ready.Load()
+}
+
+// Health returns the checker registry so custom dependency checks can be registered
+func (s *AppServer) Health() *util.HealthRegistry {
+	return s.health
+}
+
+// setupHealthRoutes registers the liveness and readiness probes
+func (s *AppServer) setupHealthRoutes() {
+	// Liveness: the process is up and serving requests
+	live := func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{
+			"status":  util.HealthStatusUp,
+			"message": "{{.ProjectName}} API is running",
+		})
 	}
+	s.app.Get("/health", live)
+	s.app.Get("/health/live", live)
+
+	// Readiness: every dependency is reachable and the server is not draining
+	s.app.Get("/health/ready", func(c *fiber.Ctx) error {
+		if !s.Ready() {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"status": "draining"})
+		}
+
+		report := s.health.Check(c.UserContext())
+		if report.Status != util.HealthStatusUp {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(report)
+		}
+		return c.JSON(report)
+	})
 }
 
+// setupDebugRoutes exposes the registered route table, compared by 'oakhouse routes --runtime'.
+// It is only registered outside production.
+func (s *AppServer) setupDebugRoutes() {
+	s.app.Get("/debug/routes", func(c *fiber.Ctx) error {
+		routes := make([]fiber.Map, 0)
+		for _, r := range s.app.GetRoutes(true) {
+			if r.Method == fiber.MethodHead {
+				continue
+			}
+			routes = append(routes, fiber.Map{
+				"method":   r.Method,
+				"path":     r.Path,
+				"name":     r.Name,
+				"handlers": len(r.Handlers),
+			})
+		}
+		return c.JSON(routes)
+	})
+}
+
+// Start serves requests until SIGINT or SIGTERM is received, then shuts down gracefully
 func (s *AppServer) Start() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	// Setup routes
+	s.setupHealthRoutes()
+	if !s.cfg.IsProduction() {
+		s.setupDebugRoutes()
+	}
 	route.SetupRoutes(s.app, s.db)
 
+	for _, hook := range s.onStart {
+		if err := hook(ctx); err != nil {
+			return errors.Join(fmt.Errorf("start hook failed: %w", err), s.Shutdown())
+		}
+	}
+
 	// Start server
 	port := s.cfg.Port
 	if port == "" {
 		port = "8080"
 	}
 
-	log.Printf("🚀 Server starting on port %s", port)
-	return s.app.Listen(fmt.Sprintf(":%s", port))
+	listenErr := make(chan error, 1)
+	go func() {
+		log.Printf("🚀 Server starting on port %s", port)
+		listenErr <- s.app.Listen(fmt.Sprintf(":%s", port))
+	}()
+	s.ready.Store(true)
+
+	var err error
+	select {
+	case <-ctx.Done():
+		log.Println("🛑 Shutdown signal received, draining in-flight requests...")
+	case err = <-listenErr:
+	}
+
+	return errors.Join(err, s.Shutdown())
+}
+
+// Shutdown marks the server as not ready, waits for the drain delay so load balancers
+// stop routing to it, drains in-flight requests and runs the stop hooks in reverse order
+func (s *AppServer) Shutdown() error {
+	s.ready.Store(false)
+	time.Sleep(s.cfg.ShutdownDrainDelay)
+
+	timeout := s.cfg.ShutdownTimeout
+	var errs []error
+	if err := s.app.ShutdownWithTimeout(timeout); err != nil {
+		errs = append(errs, fmt.Errorf("failed to drain requests: %w", err))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	for i := len(s.onStop) - 1; i >= 0; i-- {
+		if err := s.onStop[i](ctx); err != nil {
+			errs = append(errs, fmt.Errorf("stop hook failed: %w", err))
+		}
+	}
+
+	log.Println("👋 Server stopped")
+	return errors.Join(errs...)
 }
 `
 
@@ -231,7 +424,6 @@ import (
 	"{{.ProjectName}}/config"
 	"{{.ProjectName}}/adapter"
 	"github.com/google/wire"
-	"gorm.io/gorm"
 )
 
 // Wire providers
@@ -251,39 +443,52 @@ func InitializeApp() (*AppServer, error) {
 const EnvConfigTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
 package config
 
-import "os"
+import (
+	"fmt"
+	"time"
+)
 
+// Config holds the application settings, bound from struct tags by Load.
+// Integrations add their own settings with RegisterSection instead of editing this struct.
 type Config struct {
-	DBHost     string
-	DBPort     string
-	DBUser     string
-	DBPassword string
-	DBName     string
-	DBSSLMode  string
-	Port       string
-	Env        string
-	JWTSecret  string
+	// Database
+	DBHost     string ` + "`env:\"DB_HOST\" default:\"localhost\"`" + `
+	DBPort     string ` + "`env:\"DB_PORT\" default:\"5432\"`" + `
+	DBUser     string ` + "`env:\"DB_USER\" default:\"postgres\"`" + `
+	DBPassword string ` + "`env:\"DB_PASSWORD\" secret:\"true\"`" + `
+	DBName     string ` + "`env:\"DB_NAME\" default:\"{{.ProjectName}}_db\"`" + `
+	DBSSLMode  string ` + "`env:\"DB_SSL_MODE\" default:\"disable\"`" + `
+
+	// Server
+	Port               string        ` + "`env:\"PORT\" default:\"8080\"`" + `
+	Env                string        ` + "`env:\"ENV\" default:\"development\"`" + `
+	ShutdownTimeout    time.Duration ` + "`env:\"SHUTDOWN_TIMEOUT\" default:\"15s\"`" + `
+	ShutdownDrainDelay time.Duration ` + "`env:\"SHUTDOWN_DRAIN_DELAY\" default:\"0s\"`" + `
+
+	// Health checks
+	HealthCheckTimeout time.Duration ` + "`env:\"HEALTH_CHECK_TIMEOUT\" default:\"2s\"`" + `
+	HealthCacheTTL     time.Duration ` + "`env:\"HEALTH_CACHE_TTL\" default:\"5s\"`" + `
+
+	// Authentication
+	JWTSecret string ` + "`env:\"JWT_SECRET\" secret:\"true\"`" + `
 }
 
-func LoadConfig() *Config {
-	return &Config{
-		DBHost:     getEnv("DB_HOST", "localhost"),
-		DBPort:     getEnv("DB_PORT", "5432"),
-		DBUser:     getEnv("DB_USER", "postgres"),
-		DBPassword: getEnv("DB_PASSWORD", "password"),
-		DBName:     getEnv("DB_NAME", "{{.ProjectName}}_db"),
-		DBSSLMode:  getEnv("DB_SSL_MODE", "disable"),
-		Port:       getEnv("PORT", "8080"),
-		Env:        getEnv("ENV", "development"),
-		JWTSecret:  getEnv("JWT_SECRET", "your-secret-key"),
+// LoadConfig loads the settings of the active profile and of every registered section.
+// It fails fast, listing every missing or invalid setting.
+func LoadConfig() (*Config, error) {
+	cfg := &Config{}
+	if err := Load(cfg); err != nil {
+		return nil, fmt.Errorf("invalid configuration:\n%w", err)
+	}
+	if err := LoadSections(); err != nil {
+		return nil, fmt.Errorf("invalid configuration:\n%w", err)
 	}
+	return cfg, nil
 }
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
+// IsProduction reports whether the production profile is active
+func (c *Config) IsProduction() bool {
+	return c.Env == "production"
 }
 `
 
@@ -299,14 +504,6 @@ import (
 func SetupRoutes(app *fiber.App, db *gorm.DB) {
 	// Serve static files
 	app.Static("/", "./static")
-	
-	// Health check
-	app.Get("/health", func(c *fiber.Ctx) error {
-		return c.JSON(fiber.Map{
-			"status": "ok",
-			"message": "{{.ProjectName}} API is running",
-		})
-	})
 
 }
 `
@@ -506,14 +703,16 @@ const AuthMiddlewareTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakh
 package middleware
 
 import (
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
 )
 
 // AuthMiddleware handles authentication
 func AuthMiddleware() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		// Skip auth for health check and public routes
-		if c.Path() == "/health" || c.Path() == "/" {
+		// Skip auth for health checks and public routes
+		if strings.HasPrefix(c.Path(), "/health") || c.Path() == "/" {
 			return c.Next()
 		}
 
@@ -0,0 +1,61 @@
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package templates
+
+// FieldsUtilTemplate trims JSON responses to a sparse fieldset
+const FieldsUtilTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// ProjectFields returns v (a response struct or a slice of them) with each JSON object trimmed
+// to the keys of a comma separated fields parameter such as "id,name". The keys are expected
+// to be validated already, as the scope's SelectFields does; an empty parameter returns v unchanged.
+func ProjectFields(v interface{}, fields string) (interface{}, error) {
+	keep := make(map[string]bool)
+	for _, key := range strings.Split(fields, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keep[key] = true
+		}
+	}
+	if len(keep) == 0 {
+		return v, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	project := func(object map[string]json.RawMessage) map[string]json.RawMessage {
+		for key := range object {
+			if !keep[key] {
+				delete(object, key)
+			}
+		}
+		return object
+	}
+
+	switch {
+	case bytes.HasPrefix(data, []byte("[")):
+		var objects []map[string]json.RawMessage
+		if err := json.Unmarshal(data, &objects); err != nil {
+			return nil, err
+		}
+		for i := range objects {
+			objects[i] = project(objects[i])
+		}
+		return objects, nil
+	case bytes.HasPrefix(data, []byte("{")):
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(data, &object); err != nil {
+			return nil, err
+		}
+		return project(object), nil
+	default:
+		return v, nil
+	}
+}
+`
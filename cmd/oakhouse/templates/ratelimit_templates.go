@@ -0,0 +1,445 @@
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package templates
+
+// RateLimitUtilTemplate generates util/ratelimit.go with the limiter interface and the in-memory limiter
+const RateLimitUtilTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package util
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// SlidingWindow allows Limit requests in any Window long period
+	SlidingWindow = "sliding-window"
+
+	// TokenBucket allows bursts of Limit requests, refilled at Limit per Window
+	TokenBucket = "token-bucket"
+)
+
+// RateLimitRule is a limit applied to each client separately
+type RateLimitRule struct {
+	Name      string // counters are kept per rule, so route groups do not share them
+	Limit     int
+	Window    time.Duration
+	Algorithm string
+}
+
+// Validate reports a rule that cannot be enforced
+func (r RateLimitRule) Validate() error {
+	if r.Limit <= 0 {
+		return fmt.Errorf("rate limit %q: limit must be positive", r.Name)
+	}
+	if r.Window < time.Millisecond {
+		return fmt.Errorf("rate limit %q: window must be at least 1ms", r.Name)
+	}
+	if r.Algorithm != SlidingWindow && r.Algorithm != TokenBucket {
+		return fmt.Errorf("rate limit %q: unknown algorithm %q, use %s or %s", r.Name, r.Algorithm, SlidingWindow, TokenBucket)
+	}
+	return nil
+}
+
+// RateLimitResult is the outcome of counting one request
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration // until the client has its full quota again
+	RetryAfter time.Duration // until a rejected client may retry
+}
+
+// RateLimiter counts requests per client key and rule
+type RateLimiter interface {
+	Allow(ctx context.Context, key string, rule RateLimitRule) (RateLimitResult, error)
+}
+
+// rateLimitSweepInterval is how often idle clients are dropped from a MemoryRateLimiter
+const rateLimitSweepInterval = time.Minute
+
+// MemoryRateLimiter counts requests in process memory. Every instance of the API counts on its
+// own, so use RedisRateLimiter when more than one instance serves traffic.
+type MemoryRateLimiter struct {
+	mu        sync.Mutex
+	clients   map[string]*memoryRateCounter
+	lastSweep time.Time
+}
+
+// memoryRateCounter is the state of one client under one rule
+type memoryRateCounter struct {
+	hits    []time.Time // sliding window: times of the requests inside the window
+	tokens  float64     // token bucket: tokens left at updated
+	updated time.Time
+	expires time.Time
+}
+
+// NewMemoryRateLimiter creates an in-memory rate limiter
+func NewMemoryRateLimiter() *MemoryRateLimiter {
+	return &MemoryRateLimiter{clients: make(map[string]*memoryRateCounter)}
+}
+
+// Allow counts a request of key under rule
+func (l *MemoryRateLimiter) Allow(ctx context.Context, key string, rule RateLimitRule) (RateLimitResult, error) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	counterKey := rule.Name + ":" + key
+	counter, ok := l.clients[counterKey]
+	if !ok {
+		counter = &memoryRateCounter{tokens: float64(rule.Limit), updated: now}
+		l.clients[counterKey] = counter
+	}
+	counter.expires = now.Add(rule.Window)
+
+	if rule.Algorithm == TokenBucket {
+		return counter.takeToken(now, rule), nil
+	}
+	return counter.addHit(now, rule), nil
+}
+
+// sweep drops clients whose counters have fully reset
+func (l *MemoryRateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, counter := range l.clients {
+		if now.After(counter.expires) {
+			delete(l.clients, key)
+		}
+	}
+}
+
+// addHit records a request in the sliding window unless the window is full
+func (c *memoryRateCounter) addHit(now time.Time, rule RateLimitRule) RateLimitResult {
+	start := now.Add(-rule.Window)
+	kept := c.hits[:0]
+	for _, hit := range c.hits {
+		if hit.After(start) {
+			kept = append(kept, hit)
+		}
+	}
+	c.hits = kept
+
+	result := RateLimitResult{Limit: rule.Limit}
+	if len(c.hits) >= rule.Limit {
+		result.RetryAfter = c.hits[0].Add(rule.Window).Sub(now)
+		result.Reset = result.RetryAfter
+		return result
+	}
+
+	c.hits = append(c.hits, now)
+	result.Allowed = true
+	result.Remaining = rule.Limit - len(c.hits)
+	result.Reset = c.hits[0].Add(rule.Window).Sub(now)
+	return result
+}
+
+// takeToken refills the bucket for the time since the last request and takes a token
+func (c *memoryRateCounter) takeToken(now time.Time, rule RateLimitRule) RateLimitResult {
+	perNanosecond := float64(rule.Limit) / float64(rule.Window)
+	c.tokens = math.Min(float64(rule.Limit), c.tokens+float64(now.Sub(c.updated))*perNanosecond)
+	c.updated = now
+
+	result := RateLimitResult{Limit: rule.Limit}
+	if c.tokens >= 1 {
+		c.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration(math.Ceil((1 - c.tokens) / perNanosecond))
+	}
+	result.Remaining = int(c.tokens)
+	result.Reset = time.Duration(math.Ceil((float64(rule.Limit) - c.tokens) / perNanosecond))
+	return result
+}
+`
+
+// RateLimitRedisUtilTemplate generates util/ratelimit_redis.go, the limiter shared by every instance through Redis
+const RateLimitRedisUtilTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package util
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// rateLimitKeyPrefix namespaces rate limit counters in Redis
+const rateLimitKeyPrefix = "ratelimit:"
+
+// slidingWindowScript keeps the request times of the window in a sorted set. It returns
+// {allowed, remaining, retry after ms, reset ms}.
+var slidingWindowScript = redis.NewScript(` + "`" + `
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now - window)
+local count = redis.call("ZCARD", KEYS[1])
+if count >= limit then
+	local oldest = redis.call("ZRANGE", KEYS[1], 0, 0, "WITHSCORES")
+	local retry = tonumber(oldest[2]) + window - now
+	return {0, 0, retry, retry}
+end
+
+redis.call("ZADD", KEYS[1], now, ARGV[4])
+redis.call("PEXPIRE", KEYS[1], window)
+local oldest = redis.call("ZRANGE", KEYS[1], 0, 0, "WITHSCORES")
+return {1, limit - count - 1, 0, tonumber(oldest[2]) + window - now}
+` + "`" + `)
+
+// tokenBucketScript keeps the tokens left and the time they were counted in a hash. It returns
+// {allowed, remaining, retry after ms, reset ms}.
+var tokenBucketScript = redis.NewScript(` + "`" + `
+local limit = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local rate = limit / window
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "updated")
+local tokens = tonumber(state[1]) or limit
+local updated = tonumber(state[2]) or now
+tokens = math.min(limit, tokens + math.max(0, now - updated) * rate)
+
+local allowed, retry = 0, 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) / rate)
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "updated", now)
+redis.call("PEXPIRE", KEYS[1], window)
+return {allowed, math.floor(tokens), retry, math.ceil((limit - tokens) / rate)}
+` + "`" + `)
+
+// RedisRateLimiter counts requests in Redis, so every instance of the API shares the limits.
+// Each request is counted atomically by one Lua script.
+type RedisRateLimiter struct {
+	client *redis.Client
+}
+
+// NewRedisRateLimiter creates a rate limiter keeping its counters in Redis
+func NewRedisRateLimiter(client *redis.Client) *RedisRateLimiter {
+	return &RedisRateLimiter{client: client}
+}
+
+// Allow counts a request of key under rule
+func (l *RedisRateLimiter) Allow(ctx context.Context, key string, rule RateLimitRule) (RateLimitResult, error) {
+	now := time.Now().UnixMilli()
+	// The algorithms keep differently typed counters, so changing RATE_LIMIT_ALGORITHM starts new ones
+	keys := []string{rateLimitKeyPrefix + rule.Algorithm + ":" + rule.Name + ":" + key}
+	args := []interface{}{rule.Limit, rule.Window.Milliseconds(), now}
+
+	script := slidingWindowScript
+	if rule.Algorithm == TokenBucket {
+		script = tokenBucketScript
+	} else {
+		// Sorted set members must be unique, even for requests in the same millisecond
+		args = append(args, fmt.Sprintf("%d-%d", now, rand.Int63()))
+	}
+
+	values, err := script.Run(ctx, l.client, keys, args...).Int64Slice()
+	if err != nil {
+		return RateLimitResult{}, fmt.Errorf("failed to count request: %w", err)
+	}
+
+	return RateLimitResult{
+		Allowed:    values[0] == 1,
+		Limit:      rule.Limit,
+		Remaining:  int(values[1]),
+		RetryAfter: time.Duration(values[2]) * time.Millisecond,
+		Reset:      time.Duration(values[3]) * time.Millisecond,
+	}, nil
+}
+`
+
+// RateLimitMiddlewareTemplate generates middleware/ratelimit.go
+const RateLimitMiddlewareTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"{{.ProjectName}}/config"
+	"{{.ProjectName}}/util"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RateLimitKeyFunc returns the client a request is counted against
+type RateLimitKeyFunc func(c *fiber.Ctx) string
+
+// RateLimitByIP counts requests per client IP. Behind a proxy, set fiber.Config.ProxyHeader
+// so that c.IP() is the client's address.
+func RateLimitByIP(c *fiber.Ctx) string {
+	return "ip:" + c.IP()
+}
+
+// RateLimitByAPIKey counts requests per API key sent in header, and per IP without one.
+// Keys are hashed, so they are never stored in plain text.
+func RateLimitByAPIKey(header string) RateLimitKeyFunc {
+	return func(c *fiber.Ctx) string {
+		apiKey := c.Get(header)
+		if apiKey == "" {
+			return RateLimitByIP(c)
+		}
+		sum := sha256.Sum256([]byte(apiKey))
+		return "key:" + hex.EncodeToString(sum[:])
+	}
+}
+
+// RateLimitByUser counts requests per authenticated user, read with util.CurrentUserID,
+// and per IP for anonymous requests
+func RateLimitByUser(c *fiber.Ctx) string {
+	if userID, ok := util.CurrentUserID(c); ok {
+		return "user:" + userID
+	}
+	return RateLimitByIP(c)
+}
+
+// RateLimitKeyBy returns the key function named by RATE_LIMIT_KEY_BY
+func RateLimitKeyBy(name, apiKeyHeader string) (RateLimitKeyFunc, error) {
+	switch name {
+	case "ip":
+		return RateLimitByIP, nil
+	case "api-key":
+		return RateLimitByAPIKey(apiKeyHeader), nil
+	case "user":
+		return RateLimitByUser, nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit key %q, use ip, api-key or user", name)
+	}
+}
+
+// RateLimitWith limits the requests it handles to rule, counting each client returned by key
+// separately. Responses carry RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset and
+// RateLimit-Policy headers; rejected requests get 429 with Retry-After. When the limiter fails,
+// for example because Redis is down, requests are let through.
+func RateLimitWith(limiter util.RateLimiter, rule util.RateLimitRule, key RateLimitKeyFunc) fiber.Handler {
+	if err := rule.Validate(); err != nil {
+		panic("middleware: " + err.Error())
+	}
+	policy := fmt.Sprintf("%d;w=%d", rule.Limit, int(math.Ceil(rule.Window.Seconds())))
+
+	return func(c *fiber.Ctx) error {
+		result, err := limiter.Allow(c.UserContext(), key(c), rule)
+		if err != nil {
+			log.Printf("ratelimit: %v", err)
+			return c.Next()
+		}
+
+		c.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+		c.Set("RateLimit-Policy", policy)
+
+		if !result.Allowed {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			return fiber.NewError(fiber.StatusTooManyRequests, "rate limit exceeded, retry later")
+		}
+		return c.Next()
+	}
+}
+
+// rateLimitRoute is a RATE_LIMIT_ROUTES entry
+type rateLimitRoute struct {
+	prefix  string
+	handler fiber.Handler
+}
+
+// RateLimit applies the configured limits. Paths under a RATE_LIMIT_ROUTES prefix are limited by
+// the longest matching entry, every other path by RATE_LIMIT_REQUESTS per RATE_LIMIT_WINDOW.
+// Health probes and CORS preflight requests are never limited.
+func RateLimit(limiter util.RateLimiter, cfg *config.RateLimitConfig) fiber.Handler {
+	key, err := RateLimitKeyBy(cfg.KeyBy, cfg.APIKeyHeader)
+	if err != nil {
+		panic("middleware: " + err.Error())
+	}
+
+	var routes []rateLimitRoute
+	for _, entry := range cfg.Routes {
+		rule, err := parseRateLimitRoute(entry, cfg.Algorithm)
+		if err != nil {
+			panic("middleware: invalid RATE_LIMIT_ROUTES entry: " + err.Error())
+		}
+		routes = append(routes, rateLimitRoute{prefix: rule.Name, handler: RateLimitWith(limiter, rule, key)})
+	}
+	sort.Slice(routes, func(i, j int) bool {
+		return len(routes[i].prefix) > len(routes[j].prefix)
+	})
+
+	defaultLimit := func(c *fiber.Ctx) error { return c.Next() }
+	if cfg.Requests > 0 {
+		defaultLimit = RateLimitWith(limiter, util.RateLimitRule{
+			Name:      "default",
+			Limit:     cfg.Requests,
+			Window:    cfg.Window,
+			Algorithm: cfg.Algorithm,
+		}, key)
+	}
+
+	return func(c *fiber.Ctx) error {
+		path := c.Path()
+		if c.Method() == fiber.MethodOptions || strings.HasPrefix(path, "/health") {
+			return c.Next()
+		}
+
+		for _, route := range routes {
+			if path == route.prefix || strings.HasPrefix(path, route.prefix+"/") {
+				return route.handler(c)
+			}
+		}
+		return defaultLimit(c)
+	}
+}
+
+// parseRateLimitRoute parses "<path prefix>=<limit>/<window>", e.g. "/api/v1/auth=5/1m"
+func parseRateLimitRoute(entry, algorithm string) (util.RateLimitRule, error) {
+	prefix, limit, ok := strings.Cut(entry, "=")
+	if !ok {
+		return util.RateLimitRule{}, fmt.Errorf("%q, expected <path prefix>=<limit>/<window>", entry)
+	}
+	count, window, ok := strings.Cut(limit, "/")
+	if !ok {
+		return util.RateLimitRule{}, fmt.Errorf("%q, expected <path prefix>=<limit>/<window>", entry)
+	}
+
+	rule := util.RateLimitRule{Name: strings.TrimSuffix(strings.TrimSpace(prefix), "/"), Algorithm: algorithm}
+	var err error
+	if rule.Limit, err = strconv.Atoi(strings.TrimSpace(count)); err != nil {
+		return util.RateLimitRule{}, fmt.Errorf("%q: invalid limit %q", entry, count)
+	}
+	if rule.Window, err = time.ParseDuration(strings.TrimSpace(window)); err != nil {
+		return util.RateLimitRule{}, fmt.Errorf("%q: invalid window %q", entry, window)
+	}
+	if !strings.HasPrefix(rule.Name, "/") {
+		return util.RateLimitRule{}, fmt.Errorf("%q: path prefix must start with /", entry)
+	}
+	return rule, rule.Validate()
+}
+
+// ceilSeconds rounds a duration up to whole seconds for the rate limit headers
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+`
@@ -8,18 +8,29 @@ package repository
 import (
 	"context"
 	"{{.ProjectName}}/model"
-	"github.com/google/uuid"
+	"{{.ProjectName}}/util"{{if eq .IDKind "uuid"}}
+	"github.com/google/uuid"{{end}}
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type {{.ModelName}}Repository interface {
 	FindAll(ctx context.Context, scopes ...func(*gorm.DB) *gorm.DB) ([]model.{{.ModelName}}, error)
-	FindByID(ctx context.Context, id uuid.UUID, scopes ...func(*gorm.DB) *gorm.DB) (*model.{{.ModelName}}, error)
+	FindByID(ctx context.Context, id {{.PrimaryKey.Type}}, scopes ...func(*gorm.DB) *gorm.DB) (*model.{{.ModelName}}, error)
 	Create(ctx context.Context, {{.VarName}} *model.{{.ModelName}}) error
 	Update(ctx context.Context, {{.VarName}} *model.{{.ModelName}}) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id {{.PrimaryKey.Type}}) error{{if .Versioned}}
+	DeleteVersion(ctx context.Context, id {{.PrimaryKey.Type}}, version int64) error{{end}}
 	Count(ctx context.Context, scopes ...func(*gorm.DB) *gorm.DB) (int64, error)
 	FindWithPagination(ctx context.Context, offset, limit int, scopes ...func(*gorm.DB) *gorm.DB) ([]model.{{.ModelName}}, int64, error)
+	FindWithCursor(ctx context.Context, order []clause.OrderByColumn, cursor string, limit int, scopes ...func(*gorm.DB) *gorm.DB) ([]model.{{.ModelName}}, *util.CursorPage, error)
+	FindByIDs(ctx context.Context, ids []{{.PrimaryKey.Type}}) ([]model.{{.ModelName}}, error)
+	BulkCreate(ctx context.Context, {{.VarName}}s []*model.{{.ModelName}}, atomic bool) ([]error, error)
+	BulkUpdate(ctx context.Context, {{.VarName}}s []*model.{{.ModelName}}, atomic bool) ([]error, error)
+	BulkDelete(ctx context.Context, ids []{{.PrimaryKey.Type}}) error{{if .SoftDelete}}
+	FindTrashed(ctx context.Context, offset, limit int, scopes ...func(*gorm.DB) *gorm.DB) ([]model.{{.ModelName}}, int64, error)
+	Restore(ctx context.Context, id {{.PrimaryKey.Type}}) error
+	Purge(ctx context.Context, id {{.PrimaryKey.Type}}) error{{end}}
 }
 `
 
@@ -32,9 +43,15 @@ func New{{.ModelName}}Repository(db *gorm.DB) {{.ModelName}}Repository {
 	return &{{.VarName}}Repository{db: db}
 }
 
+// conn returns the transaction started by util.TxManager.Do when ctx carries one, so the
+// repository joins units of work spanning several repositories
+func (r *{{.VarName}}Repository) conn(ctx context.Context) *gorm.DB {
+	return util.DBFromContext(ctx, r.db)
+}
+
 func (r *{{.VarName}}Repository) FindAll(ctx context.Context, scopes ...func(*gorm.DB) *gorm.DB) ([]model.{{.ModelName}}, error) {
 	var {{.VarName}}s []model.{{.ModelName}}
-	query := r.db.WithContext(ctx)
+	query := r.conn(ctx)
 	
 	for _, scope := range scopes {
 		query = scope(query)
@@ -44,15 +61,15 @@ func (r *{{.VarName}}Repository) FindAll(ctx context.Context, scopes ...func(*go
 	return {{.VarName}}s, err
 }
 
-func (r *{{.VarName}}Repository) FindByID(ctx context.Context, id uuid.UUID, scopes ...func(*gorm.DB) *gorm.DB) (*model.{{.ModelName}}, error) {
+func (r *{{.VarName}}Repository) FindByID(ctx context.Context, id {{.PrimaryKey.Type}}, scopes ...func(*gorm.DB) *gorm.DB) (*model.{{.ModelName}}, error) {
 	var {{.VarName}} model.{{.ModelName}}
-	query := r.db.WithContext(ctx)
+	query := r.conn(ctx)
 	
 	for _, scope := range scopes {
 		query = scope(query)
 	}
 	
-	err := query.First(&{{.VarName}}, "id = ?", id).Error
+	err := query.First(&{{.VarName}}, "{{.PrimaryKey.Column}} = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -60,20 +77,54 @@ func (r *{{.VarName}}Repository) FindByID(ctx context.Context, id uuid.UUID, sco
 }
 
 func (r *{{.VarName}}Repository) Create(ctx context.Context, {{.VarName}} *model.{{.ModelName}}) error {
-	return r.db.WithContext(ctx).Create({{.VarName}}).Error
+	return r.conn(ctx).Create({{.VarName}}).Error
 }
 
 func (r *{{.VarName}}Repository) Update(ctx context.Context, {{.VarName}} *model.{{.ModelName}}) error {
-	return r.db.WithContext(ctx).Save({{.VarName}}).Error
+	return save{{.ModelName}}(r.conn(ctx), {{.VarName}})
 }
 
-func (r *{{.VarName}}Repository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&model.{{.ModelName}}{}, "id = ?", id).Error
+func (r *{{.VarName}}Repository) Delete(ctx context.Context, id {{.PrimaryKey.Type}}) error {
+	return r.conn(ctx).Delete(&model.{{.ModelName}}{}, "{{.PrimaryKey.Column}} = ?", id).Error
+}
+{{if .Versioned}}
+// DeleteVersion deletes a row only while it still has the given version, returning
+// util.ErrVersionConflict when it does not (or does not exist)
+func (r *{{.VarName}}Repository) DeleteVersion(ctx context.Context, id {{.PrimaryKey.Type}}, version int64) error {
+	result := r.conn(ctx).Where("version = ?", version).Delete(&model.{{.ModelName}}{}, "{{.PrimaryKey.Column}} = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return util.ErrVersionConflict
+	}
+	return nil
 }
 
+// save{{.ModelName}} writes every column of a row with a conditional UPDATE ... WHERE version = ?,
+// incrementing the version. util.ErrVersionConflict means another write changed the row since
+// it was read, and the row is left as it was.
+func save{{.ModelName}}(db *gorm.DB, {{.VarName}} *model.{{.ModelName}}) error {
+	version := {{.VarName}}.Version
+	{{.VarName}}.Version++
+	result := db.Model({{.VarName}}).Where("version = ?", version).Select("*").Updates({{.VarName}})
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = util.ErrVersionConflict
+	}
+	if result.Error != nil {
+		{{.VarName}}.Version = version
+	}
+	return result.Error
+}
+{{else}}
+// save{{.ModelName}} writes every column of a row
+func save{{.ModelName}}(db *gorm.DB, {{.VarName}} *model.{{.ModelName}}) error {
+	return db.Save({{.VarName}}).Error
+}
+{{end}}
 func (r *{{.VarName}}Repository) Count(ctx context.Context, scopes ...func(*gorm.DB) *gorm.DB) (int64, error) {
 	var count int64
-	query := r.db.WithContext(ctx).Model(&model.{{.ModelName}}{})
+	query := r.conn(ctx).Model(&model.{{.ModelName}}{})
 	
 	for _, scope := range scopes {
 		query = scope(query)
@@ -88,7 +139,7 @@ func (r *{{.VarName}}Repository) FindWithPagination(ctx context.Context, offset,
 	var total int64
 	
 	// Count total records
-	countQuery := r.db.WithContext(ctx).Model(&model.{{.ModelName}}{})
+	countQuery := r.conn(ctx).Model(&model.{{.ModelName}}{})
 	for _, scope := range scopes {
 		countQuery = scope(countQuery)
 	}
@@ -97,7 +148,7 @@ func (r *{{.VarName}}Repository) FindWithPagination(ctx context.Context, offset,
 	}
 	
 	// Get paginated records
-	query := r.db.WithContext(ctx).Offset(offset).Limit(limit)
+	query := r.conn(ctx).Offset(offset).Limit(limit)
 	for _, scope := range scopes {
 		query = scope(query)
 	}
@@ -105,4 +156,126 @@ func (r *{{.VarName}}Repository) FindWithPagination(ctx context.Context, offset,
 	err := query.Find(&{{.VarName}}s).Error
 	return {{.VarName}}s, total, err
 }
-`
+
+// FindWithCursor returns the page of rows after the cursor in the given order without counting,
+// which keeps deep pages on large tables as fast as the first one
+func (r *{{.VarName}}Repository) FindWithCursor(ctx context.Context, order []clause.OrderByColumn, cursor string, limit int, scopes ...func(*gorm.DB) *gorm.DB) ([]model.{{.ModelName}}, *util.CursorPage, error) {
+	var {{.VarName}}s []model.{{.ModelName}}
+	query := r.conn(ctx).Model(&model.{{.ModelName}}{})
+	for _, scope := range scopes {
+		query = scope(query)
+	}
+
+	page, err := util.KeysetPaginate(query, &{{.VarName}}s, order, cursor, limit)
+	return {{.VarName}}s, page, err
+}
+
+// FindByIDs loads the rows with the given primary keys; missing ids are simply absent
+func (r *{{.VarName}}Repository) FindByIDs(ctx context.Context, ids []{{.PrimaryKey.Type}}) ([]model.{{.ModelName}}, error) {
+	var {{.VarName}}s []model.{{.ModelName}}
+	err := r.conn(ctx).Where("{{.PrimaryKey.Column}} IN ?", ids).Find(&{{.VarName}}s).Error
+	return {{.VarName}}s, err
+}
+
+// {{.VarName}}BulkBatchSize is the number of rows per INSERT statement of a bulk create
+const {{.VarName}}BulkBatchSize = 100
+
+// BulkCreate inserts rows in one transaction. In atomic mode they are inserted in batches and
+// the first error rolls all of them back. Otherwise every row gets its own savepoint, so failing
+// rows are skipped while the others are committed; the returned slice holds each row's error.
+func (r *{{.VarName}}Repository) BulkCreate(ctx context.Context, {{.VarName}}s []*model.{{.ModelName}}, atomic bool) ([]error, error) {
+	if atomic {
+		return nil, r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+			return tx.CreateInBatches({{.VarName}}s, {{.VarName}}BulkBatchSize).Error
+		})
+	}
+
+	errs := make([]error, len({{.VarName}}s))
+	err := r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, {{.VarName}} := range {{.VarName}}s {
+			errs[i] = tx.Transaction(func(tx *gorm.DB) error {
+				return tx.Create({{.VarName}}).Error
+			})
+		}
+		return nil
+	})
+	return errs, err
+}
+
+// BulkUpdate saves rows in one transaction, all or nothing in atomic mode and row by row with
+// savepoints otherwise; the returned slice holds each row's error
+func (r *{{.VarName}}Repository) BulkUpdate(ctx context.Context, {{.VarName}}s []*model.{{.ModelName}}, atomic bool) ([]error, error) {
+	errs := make([]error, len({{.VarName}}s))
+	err := r.conn(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, {{.VarName}} := range {{.VarName}}s {
+			if atomic {
+				if err := save{{.ModelName}}(tx, {{.VarName}}); err != nil {
+					return err
+				}
+				continue
+			}
+			errs[i] = tx.Transaction(func(tx *gorm.DB) error {
+				return save{{.ModelName}}(tx, {{.VarName}})
+			})
+		}
+		return nil
+	})
+	return errs, err
+}
+
+// BulkDelete deletes the rows with the given primary keys in a single statement
+func (r *{{.VarName}}Repository) BulkDelete(ctx context.Context, ids []{{.PrimaryKey.Type}}) error {
+	return r.conn(ctx).Delete(&model.{{.ModelName}}{}, "{{.PrimaryKey.Column}} IN ?", ids).Error
+}
+{{if .SoftDelete}}
+// FindTrashed returns a page of soft deleted rows, which every other query skips
+func (r *{{.VarName}}Repository) FindTrashed(ctx context.Context, offset, limit int, scopes ...func(*gorm.DB) *gorm.DB) ([]model.{{.ModelName}}, int64, error) {
+	var {{.VarName}}s []model.{{.ModelName}}
+	var total int64
+
+	// Count total records
+	countQuery := r.conn(ctx).Unscoped().Model(&model.{{.ModelName}}{}).Where("deleted_at IS NOT NULL")
+	for _, scope := range scopes {
+		countQuery = scope(countQuery)
+	}
+	if err := countQuery.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated records
+	query := r.conn(ctx).Unscoped().Where("deleted_at IS NOT NULL").Offset(offset).Limit(limit)
+	for _, scope := range scopes {
+		query = scope(query)
+	}
+
+	err := query.Find(&{{.VarName}}s).Error
+	return {{.VarName}}s, total, err
+}
+
+// Restore clears deleted_at on a soft deleted row, returning gorm.ErrRecordNotFound when the
+// row does not exist or is not in the trash
+func (r *{{.VarName}}Repository) Restore(ctx context.Context, id {{.PrimaryKey.Type}}) error {
+	result := r.conn(ctx).Unscoped().Model(&model.{{.ModelName}}{}).
+		Where("{{.PrimaryKey.Column}} = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Purge permanently deletes a row, whether or not it was soft deleted first
+func (r *{{.VarName}}Repository) Purge(ctx context.Context, id {{.PrimaryKey.Type}}) error {
+	result := r.conn(ctx).Unscoped().Delete(&model.{{.ModelName}}{}, "{{.PrimaryKey.Column}} = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+{{end}}`
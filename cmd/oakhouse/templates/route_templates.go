@@ -9,6 +9,7 @@ import (
 	"{{.ProjectName}}/handler"
 	"{{.ProjectName}}/repository"
 	"{{.ProjectName}}/service"
+	"{{.ProjectName}}/util"
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
 )
@@ -16,10 +17,13 @@ import (
 // Setup{{.Name}}Routes sets up routes for {{.Name}} resource
 func Setup{{.Name}}Routes(api fiber.Router, db *gorm.DB) {
 	// Initialize repository
-	{{.LowerName}}Repo := repository.New{{.Name}}Repository(db)
+	{{.LowerName}}Repo := repository.New{{.Name}}Repository(db){{if .Cached}}
+	if queryCache != nil {
+		{{.LowerName}}Repo = repository.NewCached{{.Name}}Repository({{.LowerName}}Repo, db, queryCache)
+	}{{end}}
 	
 	// Initialize service
-	{{.LowerName}}Service := service.New{{.Name}}Service({{.LowerName}}Repo)
+	{{.LowerName}}Service := service.New{{.Name}}Service({{.LowerName}}Repo, util.NewTxManager(db))
 	
 	// Initialize handler
 	{{.LowerName}}Handler := handler.New{{.Name}}Handler({{.LowerName}}Service)
@@ -27,9 +31,16 @@ func Setup{{.Name}}Routes(api fiber.Router, db *gorm.DB) {
 	// Setup routes
 	{{.LowerName}}Group := api.Group("/{{.LowerName}}s")
 	{{.LowerName}}Group.Get("/", {{.LowerName}}Handler.FindAll)
+	{{.LowerName}}Group.Post("/bulk", {{.LowerName}}Handler.BulkCreate)
+	{{.LowerName}}Group.Patch("/bulk", {{.LowerName}}Handler.BulkUpdate)
+	{{.LowerName}}Group.Delete("/bulk", {{.LowerName}}Handler.BulkDelete){{if .SoftDelete}}
+	{{.LowerName}}Group.Get("/trashed", {{.LowerName}}Handler.Trashed){{end}}
 	{{.LowerName}}Group.Get("/:id", {{.LowerName}}Handler.FindById)
 	{{.LowerName}}Group.Post("/", {{.LowerName}}Handler.Create)
 	{{.LowerName}}Group.Put("/:id", {{.LowerName}}Handler.Update)
-	{{.LowerName}}Group.Delete("/:id", {{.LowerName}}Handler.Delete)
+	{{.LowerName}}Group.Patch("/:id", {{.LowerName}}Handler.Patch)
+	{{.LowerName}}Group.Delete("/:id", {{.LowerName}}Handler.Delete){{if .SoftDelete}}
+	{{.LowerName}}Group.Post("/:id/restore", {{.LowerName}}Handler.Restore)
+	{{.LowerName}}Group.Delete("/:id/purge", {{.LowerName}}Handler.Purge){{end}}
 }
 `
\ No newline at end of file
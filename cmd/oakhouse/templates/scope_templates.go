@@ -158,3 +158,431 @@ func CountScope(db *gorm.DB) (int64, error) {
 	return count, err
 }
 `
+
+// SortScopeTemplate is the shared sort parser in the root scope package
+const SortScopeTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package scope
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SortScope orders a query by a comma separated sort parameter such as "-created_at,name".
+// See ParseSort for how the parameter is validated.
+func SortScope(sortParam string, allowed map[string]string, tieBreaker string) (func(db *gorm.DB) *gorm.DB, error) {
+	columns, err := ParseSort(sortParam, allowed, tieBreaker)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(db *gorm.DB) *gorm.DB {
+		if len(columns) == 0 {
+			return db
+		}
+		return db.Clauses(clause.OrderBy{Columns: columns})
+	}, nil
+}
+
+// ParseSort turns a sort parameter into ORDER BY columns. A leading "-" sorts descending.
+// Keys are looked up in allowed (sort key to column), so only whitelisted columns reach SQL,
+// and the tie-breaker column is appended so that rows with equal sort values keep a stable
+// order across pages.
+func ParseSort(sortParam string, allowed map[string]string, tieBreaker string) ([]clause.OrderByColumn, error) {
+	var columns []clause.OrderByColumn
+	seen := make(map[string]bool)
+
+	for _, key := range strings.Split(sortParam, ",") {
+		key = strings.TrimSpace(key)
+		desc := strings.HasPrefix(key, "-")
+		key = strings.TrimLeft(key, "+-")
+		if key == "" {
+			continue
+		}
+
+		column, ok := allowed[key]
+		if !ok {
+			return nil, fmt.Errorf("cannot sort by %q, sortable fields are: %s", key, strings.Join(sortKeys(allowed), ", "))
+		}
+		if seen[column] {
+			continue
+		}
+		seen[column] = true
+		columns = append(columns, clause.OrderByColumn{
+			Column: clause.Column{Table: clause.CurrentTable, Name: column},
+			Desc:   desc,
+		})
+	}
+
+	if tieBreaker != "" && !seen[tieBreaker] {
+		columns = append(columns, clause.OrderByColumn{
+			Column: clause.Column{Table: clause.CurrentTable, Name: tieBreaker},
+		})
+	}
+	return columns, nil
+}
+
+// sortKeys lists the accepted sort keys in a stable order for error messages
+func sortKeys(allowed map[string]string) []string {
+	keys := make([]string, 0, len(allowed))
+	for key := range allowed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+`
+
+// ResourceSortTemplate declares the sortable columns of a resource
+const ResourceSortTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package {{.PackageName}}
+
+import (
+	"{{.ProjectName}}/scope"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SortableColumns maps the sort keys accepted by the {{.ModelName}} list endpoint to their columns.
+// Remove entries for columns that should not be sortable or lack an index on large tables.
+var SortableColumns = map[string]string{
+	"{{.PrimaryKey.JsonTag}}": "{{.PrimaryKey.Column}}",
+{{range .Fields}}{{if .QueryType}}	"{{.JsonTag}}": "{{.Column}}",
+{{end}}{{end}}{{if .CreatedAt}}	"created_at": "created_at",
+{{end}}{{if .UpdatedAt}}	"updated_at": "updated_at",
+{{end}}}
+
+// SortBy orders {{.ModelName}} queries by a sort parameter such as "-created_at,name",
+// breaking ties on the primary key so pages are stable
+func SortBy(sort string) (func(db *gorm.DB) *gorm.DB, error) {
+	return scope.SortScope(sort, SortableColumns, "{{.PrimaryKey.Column}}")
+}
+
+// OrderColumns returns the ORDER BY columns for a sort parameter, used for keyset pagination
+func OrderColumns(sort string) ([]clause.OrderByColumn, error) {
+	return scope.ParseSort(sort, SortableColumns, "{{.PrimaryKey.Column}}")
+}
+`
+
+// SelectScopeTemplate is the shared sparse fieldset parser in the root scope package
+const SelectScopeTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package scope
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// SelectScope loads only the columns named by a comma separated fields parameter such as
+// "id,name". Keys are looked up in allowed (response key to column), the primary key is
+// always loaded, and extra columns (such as the cursor's sort columns) are added when needed.
+// An empty parameter loads every column.
+func SelectScope(fieldsParam string, allowed map[string]string, primaryKey string, extra ...string) (func(db *gorm.DB) *gorm.DB, error) {
+	columns, err := ParseFields(fieldsParam, allowed)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(columns) == 0 {
+		return func(db *gorm.DB) *gorm.DB { return db }, nil
+	}
+
+	selected := []string{primaryKey}
+	seen := map[string]bool{primaryKey: true}
+	for _, column := range append(columns, extra...) {
+		if !seen[column] {
+			seen[column] = true
+			selected = append(selected, column)
+		}
+	}
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Select(selected)
+	}, nil
+}
+
+// ParseFields turns a fields parameter into the columns to load, rejecting keys that are not
+// in allowed so only whitelisted columns reach SQL
+func ParseFields(fieldsParam string, allowed map[string]string) ([]string, error) {
+	var columns []string
+	for _, key := range strings.Split(fieldsParam, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+
+		column, ok := allowed[key]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q, selectable fields are: %s", key, strings.Join(sortKeys(allowed), ", "))
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+`
+
+// ResourceFieldsTemplate declares the selectable columns of a resource
+const ResourceFieldsTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package {{.PackageName}}
+
+import (
+	"{{.ProjectName}}/scope"
+	"gorm.io/gorm"
+)
+
+// SelectableColumns maps the response keys accepted by the fields parameter to their columns.
+// Remove entries for columns that should never be exposed.
+var SelectableColumns = map[string]string{
+	"{{.PrimaryKey.JsonTag}}": "{{.PrimaryKey.Column}}",
+{{range .Fields}}	"{{.JsonTag}}": "{{.Column}}",
+{{end}}{{if .CreatedAt}}	"created_at": "created_at",
+{{end}}{{if .UpdatedAt}}	"updated_at": "updated_at",
+{{end}}{{if .Versioned}}	"version": "version",
+{{end}}}
+
+// SelectFields loads only the {{.ModelName}} columns named by a fields parameter such as "id,name";
+// extra columns are loaded as well without being requested
+func SelectFields(fields string, extra ...string) (func(db *gorm.DB) *gorm.DB, error) {
+	return scope.SelectScope(fields, SelectableColumns, "{{.PrimaryKey.Column}}", extra...)
+}
+`
+
+// LikeScopeTemplate is the LIKE matching shared by the filter grammar and search in the root scope package
+const LikeScopeTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package scope
+
+import (
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// likeContains matches rows whose column contains value literally. Wildcards in value are
+// escaped with "!", which needs no quoting in any SQL dialect, and the pattern always carries
+// an explicit ESCAPE clause because SQLite has no default escape character. Case-insensitive
+// matching lowers both sides instead of using the PostgreSQL-only ILIKE.
+func likeContains(column clause.Column, value string, ignoreCase bool) clause.Expression {
+	pattern := likePattern(value)
+	if ignoreCase {
+		return clause.Expr{SQL: "LOWER(?) LIKE LOWER(?) ESCAPE '!'", Vars: []interface{}{column, pattern}}
+	}
+	return clause.Expr{SQL: "? LIKE ? ESCAPE '!'", Vars: []interface{}{column, pattern}}
+}
+
+// likePattern is the pattern matching value anywhere, with its LIKE wildcards escaped
+func likePattern(value string) string {
+	return "%" + strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(value) + "%"
+}
+`
+
+// FilterScopeTemplate is the shared filter grammar parser in the root scope package
+const FilterScopeTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package scope
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FilterKind decides which operators a filterable column accepts and how its values are parsed
+type FilterKind int
+
+const (
+	FilterString FilterKind = iota
+	FilterNumber
+	FilterTime
+	FilterBool
+	FilterUUID
+)
+
+// FilterColumn is a whitelisted filter target: the column it queries and the kind of its values
+type FilterColumn struct {
+	Column string
+	Kind   FilterKind
+}
+
+// filterOperators lists the operators accepted by each kind of column
+var filterOperators = map[FilterKind][]string{
+	FilterString: {"eq", "ne", "in", "nin", "like", "ilike", "null"},
+	FilterNumber: {"eq", "ne", "gt", "gte", "lt", "lte", "in", "nin", "null"},
+	FilterTime:   {"eq", "ne", "gt", "gte", "lt", "lte", "null"},
+	FilterBool:   {"eq", "ne", "null"},
+	FilterUUID:   {"eq", "ne", "in", "nin", "null"},
+}
+
+// FilterScopes turns query parameters of the form key[op]=value, such as price[gte]=10,
+// name[ilike]=foo, status[in]=a,b or deleted_at[null]=true, into WHERE scopes. Keys are looked
+// up in allowed, so only whitelisted columns reach SQL, and values are parsed for the column's
+// kind so a malformed number or time is rejected instead of failing in the database.
+// Parameters without brackets (page, sort, ...) are ignored.
+func FilterScopes(query map[string]string, allowed map[string]FilterColumn) ([]func(db *gorm.DB) *gorm.DB, error) {
+	params := make([]string, 0, len(query))
+	for param := range query {
+		params = append(params, param)
+	}
+	sort.Strings(params)
+
+	var scopes []func(db *gorm.DB) *gorm.DB
+	for _, param := range params {
+		key, op, ok := parseFilterParam(param)
+		if !ok {
+			continue
+		}
+
+		target, ok := allowed[key]
+		if !ok {
+			return nil, fmt.Errorf("cannot filter by %q, filterable fields are: %s", key, strings.Join(filterKeys(allowed), ", "))
+		}
+		if !acceptsOperator(target.Kind, op) {
+			return nil, fmt.Errorf("unsupported filter %s[%s], %s accepts: %s", key, op, key, strings.Join(filterOperators[target.Kind], ", "))
+		}
+
+		expression, err := filterExpression(target, op, query[param])
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s[%s]: %w", key, op, err)
+		}
+		scopes = append(scopes, func(db *gorm.DB) *gorm.DB {
+			return db.Where(expression)
+		})
+	}
+	return scopes, nil
+}
+
+// parseFilterParam splits "price[gte]" into its key and operator
+func parseFilterParam(param string) (string, string, bool) {
+	open := strings.Index(param, "[")
+	if open <= 0 || !strings.HasSuffix(param, "]") {
+		return "", "", false
+	}
+	return param[:open], strings.ToLower(param[open+1 : len(param)-1]), true
+}
+
+// filterExpression builds the condition for one filter from parsed, typed values
+func filterExpression(target FilterColumn, op, raw string) (clause.Expression, error) {
+	column := clause.Column{Table: clause.CurrentTable, Name: target.Column}
+
+	switch op {
+	case "null":
+		isNull, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, err
+		}
+		if isNull {
+			return clause.Expr{SQL: "? IS NULL", Vars: []interface{}{column}}, nil
+		}
+		return clause.Expr{SQL: "? IS NOT NULL", Vars: []interface{}{column}}, nil
+	case "like", "ilike":
+		return likeContains(column, raw, op == "ilike"), nil
+	case "in", "nin":
+		var values []interface{}
+		for _, item := range strings.Split(raw, ",") {
+			value, err := parseFilterValue(target.Kind, strings.TrimSpace(item))
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		if op == "nin" {
+			return clause.Not(clause.IN{Column: column, Values: values}), nil
+		}
+		return clause.IN{Column: column, Values: values}, nil
+	}
+
+	value, err := parseFilterValue(target.Kind, raw)
+	if err != nil {
+		return nil, err
+	}
+	switch op {
+	case "ne":
+		return clause.Neq{Column: column, Value: value}, nil
+	case "gt":
+		return clause.Gt{Column: column, Value: value}, nil
+	case "gte":
+		return clause.Gte{Column: column, Value: value}, nil
+	case "lt":
+		return clause.Lt{Column: column, Value: value}, nil
+	case "lte":
+		return clause.Lte{Column: column, Value: value}, nil
+	default:
+		return clause.Eq{Column: column, Value: value}, nil
+	}
+}
+
+// parseFilterValue converts a query value to the Go type matching the column kind
+func parseFilterValue(kind FilterKind, raw string) (interface{}, error) {
+	switch kind {
+	case FilterNumber:
+		if value, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			return value, nil
+		}
+		return strconv.ParseFloat(raw, 64)
+	case FilterTime:
+		if value, err := time.Parse(time.RFC3339, raw); err == nil {
+			return value, nil
+		}
+		return time.Parse("2006-01-02", raw)
+	case FilterBool:
+		return strconv.ParseBool(raw)
+	case FilterUUID:
+		return uuid.Parse(raw)
+	default:
+		return raw, nil
+	}
+}
+
+// acceptsOperator reports whether op is valid for the column kind
+func acceptsOperator(kind FilterKind, op string) bool {
+	for _, accepted := range filterOperators[kind] {
+		if accepted == op {
+			return true
+		}
+	}
+	return false
+}
+
+// filterKeys lists the accepted filter keys in a stable order for error messages
+func filterKeys(allowed map[string]FilterColumn) []string {
+	keys := make([]string, 0, len(allowed))
+	for key := range allowed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+`
+
+// ResourceFiltersTemplate declares the filterable columns of a resource
+const ResourceFiltersTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package {{.PackageName}}
+
+import (
+	"{{.ProjectName}}/scope"
+	"gorm.io/gorm"
+)
+
+// FilterableColumns maps the keys accepted by the {{.ModelName}} filter grammar (key[op]=value) to
+// their columns and value kinds. Remove entries for columns that lack an index on large tables.
+var FilterableColumns = map[string]scope.FilterColumn{
+	"{{.PrimaryKey.JsonTag}}": {Column: "{{.PrimaryKey.Column}}", Kind: scope.Filter{{.PrimaryKey.FilterKind}}},
+{{range .Fields}}{{if .QueryType}}	"{{.JsonTag}}": {Column: "{{.Column}}", Kind: scope.Filter{{.FilterKind}}},
+{{end}}{{end}}{{if .CreatedAt}}	"created_at": {Column: "created_at", Kind: scope.FilterTime},
+{{end}}{{if .UpdatedAt}}	"updated_at": {Column: "updated_at", Kind: scope.FilterTime},
+{{end}}}
+
+// Filters parses {{.ModelName}} filter parameters such as name[ilike]=foo into query scopes
+func Filters(query map[string]string) ([]func(db *gorm.DB) *gorm.DB, error) {
+	return scope.FilterScopes(query, FilterableColumns)
+}
+`
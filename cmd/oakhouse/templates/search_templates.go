@@ -0,0 +1,111 @@
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package templates
+
+// SearchScopeTemplate is the shared full-text search scope in the root scope package
+const SearchScopeTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package scope
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SearchConfig describes full-text search over a table
+type SearchConfig struct {
+	Columns  []string // text columns searched by the LIKE fallback, most important first
+	Vector   string   // generated tsvector column over Columns, PostgreSQL only
+	Language string   // PostgreSQL text search configuration such as "english" or "simple"
+}
+
+// FullTextSearch keeps the rows matching a search query. On PostgreSQL the query is parsed with
+// websearch_to_tsquery ("quoted phrases", -exclusions, or) and matched against the indexed
+// tsvector column; other databases fall back to a case-insensitive LIKE over each column.
+func FullTextSearch(q string, config SearchConfig) func(db *gorm.DB) *gorm.DB {
+	q = strings.TrimSpace(q)
+	return func(db *gorm.DB) *gorm.DB {
+		if q == "" {
+			return db
+		}
+		if db.Dialector.Name() == "postgres" {
+			return db.Where("? @@ websearch_to_tsquery(?::regconfig, ?)", vectorColumn(config), config.Language, q)
+		}
+
+		conditions := make([]clause.Expression, len(config.Columns))
+		for i, column := range config.Columns {
+			conditions[i] = likeContains(clause.Column{Table: clause.CurrentTable, Name: column}, q, true)
+		}
+		return db.Where(clause.Or(conditions...))
+	}
+}
+
+// SearchRank orders search results by relevance with ts_rank on PostgreSQL, then by the
+// tie-breaker column. Other databases have no ranking and order by the tie-breaker only.
+func SearchRank(q string, config SearchConfig, tieBreaker string) func(db *gorm.DB) *gorm.DB {
+	q = strings.TrimSpace(q)
+	return func(db *gorm.DB) *gorm.DB {
+		tieBreakerColumn := clause.Column{Table: clause.CurrentTable, Name: tieBreaker}
+		if q == "" || db.Dialector.Name() != "postgres" {
+			byTieBreaker := clause.OrderByColumn{Column: tieBreakerColumn}
+			return db.Clauses(clause.OrderBy{Columns: []clause.OrderByColumn{byTieBreaker}})
+		}
+		return db.Clauses(clause.OrderBy{Expression: clause.Expr{
+			SQL:  "ts_rank(?, websearch_to_tsquery(?::regconfig, ?)) DESC, ?",
+			Vars: []interface{}{vectorColumn(config), config.Language, q, tieBreakerColumn},
+		}})
+	}
+}
+
+// vectorColumn is the tsvector column of the current table
+func vectorColumn(config SearchConfig) clause.Column {
+	return clause.Column{Table: clause.CurrentTable, Name: config.Vector}
+}
+`
+
+// ResourceSearchTemplate configures full-text search for a resource
+const ResourceSearchTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package {{.PackageName}}
+
+import (
+	"{{.ProjectName}}/scope"
+	"gorm.io/gorm"
+)
+
+// SearchConfig configures the q parameter of the {{.ModelName}} list endpoint. The search_vector
+// column and its GIN index are created by the search migration generated with the resource;
+// regenerate it when changing the columns or language.
+var SearchConfig = scope.SearchConfig{
+	Columns:  []string{ {{- range $i, $c := .Search.Columns}}{{if $i}}, {{end}}"{{$c}}"{{end -}} },
+	Vector:   "search_vector",
+	Language: "{{.Search.Language}}",
+}
+
+// Search keeps the {{.ModelName}}s matching a search query
+func Search(q string) func(db *gorm.DB) *gorm.DB {
+	return scope.FullTextSearch(q, SearchConfig)
+}
+
+// SearchRank orders {{.ModelName}}s by relevance to a search query, breaking ties on the primary key
+func SearchRank(q string) func(db *gorm.DB) *gorm.DB {
+	return scope.SearchRank(q, SearchConfig, "{{.PrimaryKey.Column}}")
+}
+`
+
+// SearchMigrationUpTemplate adds the generated tsvector column and its GIN index
+const SearchMigrationUpTemplate = `-- 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+-- Full-text search for {{.TableName}}: a weighted tsvector over {{.ColumnList}}
+-- that PostgreSQL keeps up to date on every write (requires PostgreSQL 12+).
+ALTER TABLE {{.TableName}}
+    ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS ({{.Expression}}) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_{{.TableName}}_search_vector ON {{.TableName}} USING GIN (search_vector);
+`
+
+// SearchMigrationDownTemplate removes the search column and index
+const SearchMigrationDownTemplate = `-- 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+DROP INDEX IF EXISTS idx_{{.TableName}}_search_vector;
+
+ALTER TABLE {{.TableName}} DROP COLUMN IF EXISTS search_vector;
+`
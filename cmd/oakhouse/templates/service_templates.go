@@ -9,7 +9,8 @@ import (
 	"context"
 	"{{.ProjectName}}/dto/{{.PackageName}}"
 	"{{.ProjectName}}/model"
-	"github.com/google/uuid"
+	"{{.ProjectName}}/util"{{if eq .IDKind "uuid"}}
+	"github.com/google/uuid"{{end}}
 )
 
 // {{.ModelName}}Service defines the interface for {{.PackageName}}-related operations
@@ -17,93 +18,501 @@ type {{.ModelName}}Service interface {
 	// FindAll retrieves all {{.PackageName}}s with optional filtering
 	FindAll(ctx context.Context, dto *{{.PackageName}}.Get{{.ModelName}}Dto) ([]model.{{.ModelName}}, int64, error)
 	
+	// FindWithCursor retrieves one keyset page of {{.PackageName}}s after dto.Cursor; the total is only counted on request
+	FindWithCursor(ctx context.Context, dto *{{.PackageName}}.Get{{.ModelName}}Dto) ([]model.{{.ModelName}}, *util.CursorPage, int64, error)
+	
 	// FindById retrieves a {{.PackageName}} by its ID
-	FindById(ctx context.Context, id uuid.UUID) (*model.{{.ModelName}}, error)
+	FindById(ctx context.Context, id {{.PrimaryKey.Type}}) (*model.{{.ModelName}}, error)
 	
 	// Create creates a new {{.PackageName}}
 	Create(ctx context.Context, dto *{{.PackageName}}.Create{{.ModelName}}Dto) (*model.{{.ModelName}}, error)
 	
-	// Update updates an existing {{.PackageName}}
-	Update(ctx context.Context, id uuid.UUID, dto *{{.PackageName}}.Update{{.ModelName}}Dto) error
+	// Update sets the fields present in the dto on an existing {{.PackageName}}; the HTTP handlers go through
+	// Replace, so this is the partial-update entry point for jobs and other services
+	Update(ctx context.Context, id {{.PrimaryKey.Type}}, dto *{{.PackageName}}.Update{{.ModelName}}Dto) error
 	
-	// Delete removes a {{.PackageName}} by its ID
-	Delete(ctx context.Context, id uuid.UUID) error
-}
+	// Replace overwrites every writable field of an existing {{.PackageName}}{{if .Versioned}}, only while it satisfies ifMatch when it is not nil{{end}}
+	Replace(ctx context.Context, id {{.PrimaryKey.Type}}, dto *{{.PackageName}}.Create{{.ModelName}}Dto{{if .Versioned}}, ifMatch *util.IfMatch{{end}}) error
+	
+	// Delete removes a {{.PackageName}} by its ID{{if .Versioned}}, only while it satisfies ifMatch when it is not nil
+	Delete(ctx context.Context, id {{.PrimaryKey.Type}}, ifMatch *util.IfMatch) error{{else}}
+	Delete(ctx context.Context, id {{.PrimaryKey.Type}}) error{{end}}
+	
+	// BulkCreate creates many {{.PackageName}}s, all or nothing when atomic, reporting each item's outcome
+	BulkCreate(ctx context.Context, dtos []{{.PackageName}}.Create{{.ModelName}}Dto, atomic bool) ([]util.BulkItemResult, error)
+	
+	// BulkUpdate updates many {{.PackageName}}s, all or nothing when atomic, reporting each item's outcome
+	BulkUpdate(ctx context.Context, items []{{.PackageName}}.BulkUpdate{{.ModelName}}Item, atomic bool) ([]util.BulkItemResult, error)
+	
+	// BulkDelete deletes many {{.PackageName}}s, all or nothing when atomic, reporting each item's outcome
+	BulkDelete(ctx context.Context, ids []{{.PrimaryKey.Type}}, atomic bool) ([]util.BulkItemResult, error)
+{{if .SoftDelete}}	
+	// FindTrashed retrieves soft deleted {{.PackageName}}s with optional filtering
+	FindTrashed(ctx context.Context, dto *{{.PackageName}}.Get{{.ModelName}}Dto) ([]model.{{.ModelName}}, int64, error)
+	
+	// Restore brings a soft deleted {{.PackageName}} back
+	Restore(ctx context.Context, id {{.PrimaryKey.Type}}) error
+	
+	// Purge permanently removes a {{.PackageName}}
+	Purge(ctx context.Context, id {{.PrimaryKey.Type}}) error
+{{end}}}
 `
 
 const ServiceTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
 package service
 
 import (
-	"context"
-	"time"
-
-	"github.com/google/uuid"
+	"context"{{if .CreatedAt}}
+	"time"{{end}}
+{{if eq .IDKind "uuid"}}
+	"github.com/google/uuid"{{end}}
 	"gorm.io/gorm"
 	dto "{{.ProjectName}}/dto/{{.PackageName}}"
 	"{{.ProjectName}}/model"
 	"{{.ProjectName}}/repository"
 	tscope "{{.ProjectName}}/scope/{{.PackageName}}"
+	"{{.ProjectName}}/util"
 )
 
 type {{.VarName}}Service struct {
 	repo repository.{{.ModelName}}Repository
+	tx   *util.TxManager
 }
 
-func New{{.ModelName}}Service(repo repository.{{.ModelName}}Repository) {{.ModelName}}Service {
-	return &{{.VarName}}Service{repo: repo}
+func New{{.ModelName}}Service(repo repository.{{.ModelName}}Repository, tx *util.TxManager) {{.ModelName}}Service {
+	return &{{.VarName}}Service{repo: repo, tx: tx}
 }
 
-func (s *{{.VarName}}Service) FindAll(ctx context.Context, getDto *dto.Get{{.ModelName}}Dto) ([]model.{{.ModelName}}, int64, error) {
-	scopes := s.buildScopes(getDto)
+func (s *{{.VarName}}Service) FindAll(ctx context.Context, getDto *dto.Get{{.ModelName}}Dto) ({{if .Tracing}}_ {{end}}[]model.{{.ModelName}}, {{if .Tracing}}_ {{end}}int64, {{if .Tracing}}err {{end}}error) {
+{{if .Tracing}}	ctx, span := util.StartSpan(ctx, "{{.ModelName}}Service.FindAll")
+	defer util.EndSpan(span, &err)
+
+{{end}}	scopes, err := s.buildScopes(getDto)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Order by the requested sort keys, always ending with the primary key
+	sortScope, err := tscope.SortBy(getDto.Sort)
+	if err != nil {
+		return nil, 0, err
+	}
+{{if .Search}}
+	// Without an explicit sort, search results are ranked by relevance
+	if getDto.Q != "" && getDto.Sort == "" {
+		sortScope = tscope.SearchRank(getDto.Q)
+	}
+{{end}}	scopes = append(scopes, sortScope)
+
+	// Load only the requested fields
+	selectScope, err := tscope.SelectFields(getDto.Fields)
+	if err != nil {
+		return nil, 0, err
+	}
+	scopes = append(scopes, selectScope)
+
 	offset := (*getDto.Page - 1) * *getDto.PageSize
+	if !getDto.WithCount() {
+		// count=false skips the COUNT query on large tables
+		{{.VarName}}s, err := s.repo.FindAll(ctx, append(scopes, func(db *gorm.DB) *gorm.DB {
+			return db.Offset(offset).Limit(*getDto.PageSize)
+		})...)
+		return {{.VarName}}s, 0, err
+	}
 	return s.repo.FindWithPagination(ctx, offset, *getDto.PageSize, scopes...)
 }
 
-func (s *{{.VarName}}Service) FindById(ctx context.Context, id uuid.UUID) (*model.{{.ModelName}}, error) {
-	return s.repo.FindByID(ctx, id)
+func (s *{{.VarName}}Service) FindWithCursor(ctx context.Context, getDto *dto.Get{{.ModelName}}Dto) ({{if .Tracing}}_ {{end}}[]model.{{.ModelName}}, {{if .Tracing}}_ {{end}}*util.CursorPage, {{if .Tracing}}_ {{end}}int64, {{if .Tracing}}err {{end}}error) {
+{{if .Tracing}}	ctx, span := util.StartSpan(ctx, "{{.ModelName}}Service.FindWithCursor")
+	defer util.EndSpan(span, &err)
+
+{{end}}	scopes, err := s.buildScopes(getDto)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	// The cursor encodes the values of the sort columns, which always end with the primary key
+	order, err := tscope.OrderColumns(getDto.Sort)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	// Load only the requested fields, plus the sort columns the next cursor is built from
+	sortColumns := make([]string, len(order))
+	for i, column := range order {
+		sortColumns[i] = column.Column.Name
+	}
+	selectScope, err := tscope.SelectFields(getDto.Fields, sortColumns...)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	{{.VarName}}s, page, err := s.repo.FindWithCursor(ctx, order, getDto.Cursor, *getDto.Limit, append(scopes, selectScope)...)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	var total int64
+	if getDto.WithCount() {
+		if total, err = s.repo.Count(ctx, scopes...); err != nil {
+			return nil, nil, 0, err
+		}
+	}
+	return {{.VarName}}s, page, total, nil
+}
+
+func (s *{{.VarName}}Service) FindById(ctx context.Context, id {{.PrimaryKey.Type}}) ({{if .Tracing}}_ {{end}}*model.{{.ModelName}}, {{if .Tracing}}err {{end}}error) {
+{{if .Tracing}}	ctx, span := util.StartSpan(ctx, "{{.ModelName}}Service.FindById")
+	defer util.EndSpan(span, &err)
+
+{{end}}	return s.repo.FindByID(ctx, id)
 }
 
-func (s *{{.VarName}}Service) Create(ctx context.Context, createDto *dto.Create{{.ModelName}}Dto) (*model.{{.ModelName}}, error) {
-	new{{.ModelName}} := &model.{{.ModelName}}{
-{{range .Fields}}		{{.Name}}: createDto.{{.Name}},
-{{end}}	}
+func (s *{{.VarName}}Service) Create(ctx context.Context, createDto *dto.Create{{.ModelName}}Dto) ({{if .Tracing}}_ {{end}}*model.{{.ModelName}}, {{if .Tracing}}err {{end}}error) {
+{{if .Tracing}}	ctx, span := util.StartSpan(ctx, "{{.ModelName}}Service.Create")
+	defer util.EndSpan(span, &err)
+
+{{end}}	new{{.ModelName}} := new{{.ModelName}}FromDto(createDto)
 	
 	if err := s.repo.Create(ctx, new{{.ModelName}}); err != nil {
 		return nil, err
 	}
-	
+{{if .Events}}	util.PublishEvent(ctx, "{{.PackageName}}.created", new{{.ModelName}})
+{{end}}	
 	return new{{.ModelName}}, nil
 }
 
-func (s *{{.VarName}}Service) Update(ctx context.Context, id uuid.UUID, updateDto *dto.Update{{.ModelName}}Dto) error {
-	existing{{.ModelName}}, err := s.repo.FindByID(ctx, id)
-	if err != nil {
+func (s *{{.VarName}}Service) Update(ctx context.Context, id {{.PrimaryKey.Type}}, updateDto *dto.Update{{.ModelName}}Dto) {{if .Tracing}}(err error){{else}}error{{end}} {
+{{if .Tracing}}	ctx, span := util.StartSpan(ctx, "{{.ModelName}}Service.Update")
+	defer util.EndSpan(span, &err)
+
+{{end}}	// Read and write in one transaction so the merge is based on the row being saved
+	return s.tx.Do(ctx, func(ctx context.Context) error {
+		existing{{.ModelName}}, err := s.repo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+{{if .Versioned}}		if updateDto.Version != nil && *updateDto.Version != existing{{.ModelName}}.Version {
+			return util.ErrVersionConflict
+		}
+{{end}}		
+		apply{{.ModelName}}Update(existing{{.ModelName}}, updateDto)
+		
+{{if .Events}}		if err := s.repo.Update(ctx, existing{{.ModelName}}); err != nil {
+			return err
+		}
+		util.PublishEvent(ctx, "{{.PackageName}}.updated", existing{{.ModelName}})
+		return nil
+{{else}}		return s.repo.Update(ctx, existing{{.ModelName}})
+{{end}}	})
+}
+
+func (s *{{.VarName}}Service) Replace(ctx context.Context, id {{.PrimaryKey.Type}}, replaceDto *dto.Create{{.ModelName}}Dto{{if .Versioned}}, ifMatch *util.IfMatch{{end}}) {{if .Tracing}}(err error){{else}}error{{end}} {
+{{if .Tracing}}	ctx, span := util.StartSpan(ctx, "{{.ModelName}}Service.Replace")
+	defer util.EndSpan(span, &err)
+
+{{end}}	return s.tx.Do(ctx, func(ctx context.Context) error {
+		existing{{.ModelName}}, err := s.repo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+{{if .Versioned}}		if !ifMatch.Matches(existing{{.ModelName}}.Version) {
+			return util.ErrVersionConflict
+		}
+{{end}}		
+		// The primary key comes from the path, never from the body
+{{range .Fields}}		existing{{$.ModelName}}.{{.Name}} = replaceDto.{{.Name}}
+{{end}}		
+{{if .Events}}		if err := s.repo.Update(ctx, existing{{.ModelName}}); err != nil {
+			return err
+		}
+		util.PublishEvent(ctx, "{{.PackageName}}.updated", existing{{.ModelName}})
+		return nil
+{{else}}		return s.repo.Update(ctx, existing{{.ModelName}})
+{{end}}	})
+}
+
+func (s *{{.VarName}}Service) Delete(ctx context.Context, id {{.PrimaryKey.Type}}{{if .Versioned}}, ifMatch *util.IfMatch{{end}}) {{if .Tracing}}(err error){{else}}error{{end}} {
+{{if .Tracing}}	ctx, span := util.StartSpan(ctx, "{{.ModelName}}Service.Delete")
+	defer util.EndSpan(span, &err)
+
+{{end}}{{if .Versioned}}	if ifMatch != nil {
+		// Look the row up first so a missing row is reported as not found rather than a conflict
+		return s.tx.Do(ctx, func(ctx context.Context) error {
+			existing{{.ModelName}}, err := s.repo.FindByID(ctx, id)
+			if err != nil {
+				return err
+			}
+			if !ifMatch.Matches(existing{{.ModelName}}.Version) {
+				return util.ErrVersionConflict
+			}
+{{if .Events}}			if err := s.repo.DeleteVersion(ctx, id, existing{{.ModelName}}.Version); err != nil {
+				return err
+			}
+			util.PublishEvent(ctx, "{{.PackageName}}.deleted", map[string]interface{}{"id": id})
+			return nil
+{{else}}			return s.repo.DeleteVersion(ctx, id, existing{{.ModelName}}.Version)
+{{end}}		})
+	}
+{{end}}{{if .Events}}	if err := s.repo.Delete(ctx, id); err != nil {
 		return err
 	}
-	
-{{range .Fields}}	if updateDto.{{.Name}} != nil {
-		existing{{$.ModelName}}.{{.Name}} = *updateDto.{{.Name}}
+	util.PublishEvent(ctx, "{{.PackageName}}.deleted", map[string]interface{}{"id": id})
+	return nil{{else}}	return s.repo.Delete(ctx, id){{end}}
+}
+
+func (s *{{.VarName}}Service) BulkCreate(ctx context.Context, createDtos []dto.Create{{.ModelName}}Dto, atomic bool) ({{if .Tracing}}_ {{end}}[]util.BulkItemResult, {{if .Tracing}}err {{end}}error) {
+{{if .Tracing}}	ctx, span := util.StartSpan(ctx, "{{.ModelName}}Service.BulkCreate")
+	defer util.EndSpan(span, &err)
+
+{{end}}	results := make([]util.BulkItemResult, len(createDtos))
+	var rows []*model.{{.ModelName}}
+	var indexes []int
+	for i := range createDtos {
+		results[i].Index = i
+		if errs := createDtos[i].Validate(); errs != nil {
+			results[i].Status = util.BulkInvalid
+			results[i].Errors = errs
+			continue
+		}
+		rows = append(rows, new{{.ModelName}}FromDto(&createDtos[i]))
+		indexes = append(indexes, i)
 	}
-{{end}}	
-	return s.repo.Update(ctx, existing{{.ModelName}})
+
+	if atomic && len(rows) < len(createDtos) {
+		util.SkipPending(results)
+		return results, util.ErrBulkRolledBack
+	}
+	if len(rows) == 0 {
+		return results, nil
+	}
+
+	rowErrs, err := s.repo.BulkCreate(ctx, rows, atomic)
+	if err != nil {
+		util.SkipPending(results)
+		return results, err
+	}
+	for j, i := range indexes {
+		if rowErrs != nil && rowErrs[j] != nil {
+			results[i].Status = util.BulkFailed
+			results[i].Error = rowErrs[j].Error()
+			continue
+		}
+		results[i].Status = util.BulkCreated
+		results[i].ID = rows[j].{{.PrimaryKey.Name}}
+{{if .Events}}		util.PublishEvent(ctx, "{{.PackageName}}.created", rows[j])
+{{end}}	}
+	return results, nil
 }
 
-func (s *{{.VarName}}Service) Delete(ctx context.Context, id uuid.UUID) error {
-	return s.repo.Delete(ctx, id)
+func (s *{{.VarName}}Service) BulkUpdate(ctx context.Context, items []dto.BulkUpdate{{.ModelName}}Item, atomic bool) ({{if .Tracing}}_ {{end}}[]util.BulkItemResult, {{if .Tracing}}err {{end}}error) {
+{{if .Tracing}}	ctx, span := util.StartSpan(ctx, "{{.ModelName}}Service.BulkUpdate")
+	defer util.EndSpan(span, &err)
+
+{{end}}	// Load and write the rows in one transaction so every item is checked against the row it saves
+	var results []util.BulkItemResult
+	if err := s.tx.Do(ctx, func(ctx context.Context) error {
+		ids := make([]{{.PrimaryKey.Type}}, len(items))
+		for i := range items {
+			ids[i] = items[i].{{.PrimaryKey.Name}}
+		}
+		existing, err := s.repo.FindByIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+		byID := make(map[{{.PrimaryKey.Type}}]*model.{{.ModelName}}, len(existing))
+		for i := range existing {
+			byID[existing[i].{{.PrimaryKey.Name}}] = &existing[i]
+		}
+
+		results = make([]util.BulkItemResult, len(items))
+		seen := make(map[{{.PrimaryKey.Type}}]bool, len(items))
+		var rows []*model.{{.ModelName}}
+		var indexes []int
+		for i := range items {
+			id := items[i].{{.PrimaryKey.Name}}
+			results[i].Index = i
+			results[i].ID = id
+			if seen[id] {
+				results[i].Status = util.BulkInvalid
+				results[i].Error = "duplicate id, each {{.PackageName}} can be updated once per request"
+				continue
+			}
+			seen[id] = true
+			row, ok := byID[id]
+			if !ok {
+				results[i].Status = util.BulkNotFound
+				continue
+			}
+{{if .Versioned}}			if items[i].Version != nil && *items[i].Version != row.Version {
+				results[i].Status = util.BulkFailed
+				results[i].Error = util.ErrVersionConflict.Error()
+				continue
+			}
+{{end}}			if errs := items[i].Validate(); errs != nil {
+				results[i].Status = util.BulkInvalid
+				results[i].Errors = errs
+				continue
+			}
+			apply{{.ModelName}}Update(row, &items[i].Update{{.ModelName}}Dto)
+			rows = append(rows, row)
+			indexes = append(indexes, i)
+		}
+
+		if atomic && len(rows) < len(items) {
+			util.SkipPending(results)
+			return util.ErrBulkRolledBack
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		rowErrs, err := s.repo.BulkUpdate(ctx, rows, atomic)
+		if err != nil {
+			util.SkipPending(results)
+			return err
+		}
+		for j, i := range indexes {
+			if rowErrs != nil && rowErrs[j] != nil {
+				results[i].Status = util.BulkFailed
+				results[i].Error = rowErrs[j].Error()
+				continue
+			}
+			results[i].Status = util.BulkUpdated
+{{if .Events}}			util.PublishEvent(ctx, "{{.PackageName}}.updated", rows[j])
+{{end}}		}
+		return nil
+	}); err != nil {
+		return results, err
+	}
+	return results, nil
 }
 
-func (s *{{.VarName}}Service) buildScopes(getDto *dto.Get{{.ModelName}}Dto) []func(*gorm.DB) *gorm.DB {
-	var scopes []func(*gorm.DB) *gorm.DB
-	
+func (s *{{.VarName}}Service) BulkDelete(ctx context.Context, ids []{{.PrimaryKey.Type}}, atomic bool) ({{if .Tracing}}_ {{end}}[]util.BulkItemResult, {{if .Tracing}}err {{end}}error) {
+{{if .Tracing}}	ctx, span := util.StartSpan(ctx, "{{.ModelName}}Service.BulkDelete")
+	defer util.EndSpan(span, &err)
+
+{{end}}	// Look the rows up in the deleting transaction so a row removed meanwhile is reported as not found
+	var results []util.BulkItemResult
+	if err := s.tx.Do(ctx, func(ctx context.Context) error {
+		existing, err := s.repo.FindByIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+		found := make(map[{{.PrimaryKey.Type}}]bool, len(existing))
+		for _, row := range existing {
+			found[row.{{.PrimaryKey.Name}}] = true
+		}
+
+		results = make([]util.BulkItemResult, len(ids))
+		seen := make(map[{{.PrimaryKey.Type}}]bool, len(ids))
+		var deleteIDs []{{.PrimaryKey.Type}}
+		for i, id := range ids {
+			results[i] = util.BulkItemResult{Index: i, ID: id}
+			if seen[id] {
+				results[i].Status = util.BulkInvalid
+				results[i].Error = "duplicate id, each {{.PackageName}} can be deleted once per request"
+				continue
+			}
+			seen[id] = true
+			if !found[id] {
+				results[i].Status = util.BulkNotFound
+				continue
+			}
+			deleteIDs = append(deleteIDs, id)
+		}
+
+		if atomic && len(deleteIDs) < len(ids) {
+			util.SkipPending(results)
+			return util.ErrBulkRolledBack
+		}
+		if len(deleteIDs) > 0 {
+			if err := s.repo.BulkDelete(ctx, deleteIDs); err != nil {
+				util.SkipPending(results)
+				return err
+			}
+		}
+		for i := range results {
+			if results[i].Status == "" {
+				results[i].Status = util.BulkDeleted
+{{if .Events}}				util.PublishEvent(ctx, "{{.PackageName}}.deleted", map[string]interface{}{"id": results[i].ID})
+{{end}}			}
+		}
+		return nil
+	}); err != nil {
+		return results, err
+	}
+	return results, nil
+}
+{{if .SoftDelete}}
+func (s *{{.VarName}}Service) FindTrashed(ctx context.Context, getDto *dto.Get{{.ModelName}}Dto) ({{if .Tracing}}_ {{end}}[]model.{{.ModelName}}, {{if .Tracing}}_ {{end}}int64, {{if .Tracing}}err {{end}}error) {
+{{if .Tracing}}	ctx, span := util.StartSpan(ctx, "{{.ModelName}}Service.FindTrashed")
+	defer util.EndSpan(span, &err)
+
+{{end}}	scopes, err := s.buildScopes(getDto)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sortScope, err := tscope.SortBy(getDto.Sort)
+	if err != nil {
+		return nil, 0, err
+	}
+	scopes = append(scopes, sortScope)
+
+	offset := (*getDto.Page - 1) * *getDto.PageSize
+	return s.repo.FindTrashed(ctx, offset, *getDto.PageSize, scopes...)
+}
+
+func (s *{{.VarName}}Service) Restore(ctx context.Context, id {{.PrimaryKey.Type}}) {{if .Tracing}}(err error){{else}}error{{end}} {
+{{if .Tracing}}	ctx, span := util.StartSpan(ctx, "{{.ModelName}}Service.Restore")
+	defer util.EndSpan(span, &err)
+
+{{end}}	return s.repo.Restore(ctx, id)
+}
+
+func (s *{{.VarName}}Service) Purge(ctx context.Context, id {{.PrimaryKey.Type}}) {{if .Tracing}}(err error){{else}}error{{end}} {
+{{if .Tracing}}	ctx, span := util.StartSpan(ctx, "{{.ModelName}}Service.Purge")
+	defer util.EndSpan(span, &err)
+
+{{end}}	return s.repo.Purge(ctx, id)
+}
+{{end}}
+// new{{.ModelName}}FromDto maps a create request onto a new {{.ModelName}}
+func new{{.ModelName}}FromDto(createDto *dto.Create{{.ModelName}}Dto) *model.{{.ModelName}} {
+	return &model.{{.ModelName}}{
+{{if .AssignedKey}}		{{.PrimaryKey.Name}}: createDto.{{.PrimaryKey.Name}},
+{{end}}{{range .Fields}}		{{.Name}}: createDto.{{.Name}},
+{{end}}	}
+}
+
+// apply{{.ModelName}}Update copies the fields set in an update request onto an existing {{.ModelName}}
+func apply{{.ModelName}}Update(existing *model.{{.ModelName}}, updateDto *dto.Update{{.ModelName}}Dto) {
+{{range .Fields}}	if updateDto.{{.Name}} != nil {
+		existing.{{.Name}} = {{if not .Pointer}}*{{end}}updateDto.{{.Name}}
+	}
+{{end}}}
+
+func (s *{{.VarName}}Service) buildScopes(getDto *dto.Get{{.ModelName}}Dto) ([]func(*gorm.DB) *gorm.DB, error) {
+	// Add filter grammar conditions such as price[gte]=10, checked against FilterableColumns
+	scopes, err := tscope.Filters(getDto.Filters)
+	if err != nil {
+		return nil, err
+	}
+{{if .Search}}
+	// Add full-text search
+	if getDto.Q != "" {
+		scopes = append(scopes, tscope.Search(getDto.Q))
+	}
+{{end}}	
 	// Add field-specific filters
-{{range .Fields}}{{if eq .Type "string"}}	if getDto.{{.Name}} != nil && *getDto.{{.Name}} != "" {
+{{range .QueryFields}}{{if eq .Type "string"}}	if getDto.{{.Name}} != nil && *getDto.{{.Name}} != "" {
 		scopes = append(scopes, tscope.FilterBy{{.Name}}(*getDto.{{.Name}}))
 	}
 {{else}}	if getDto.{{.Name}} != nil {
 		scopes = append(scopes, tscope.FilterBy{{.Name}}(*getDto.{{.Name}}))
 	}
-{{end}}{{end}}	
+{{end}}{{end}}{{if .CreatedAt}}	
 	// Add date range filtering if available
 	if getDto.StartDate != nil || getDto.EndDate != nil {
 		var startTime, endTime time.Time
@@ -115,8 +524,8 @@ func (s *{{.VarName}}Service) buildScopes(getDto *dto.Get{{.ModelName}}Dto) []fu
 		}
 		scopes = append(scopes, tscope.FilterByDateRange(startTime, endTime))
 	}
-	
-	return scopes
+{{end}}	
+	return scopes, nil
 }
 `
 
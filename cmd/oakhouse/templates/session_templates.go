@@ -0,0 +1,249 @@
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package templates
+
+// CurrentUserUtilTemplate generates util/user.go, shared by the middleware that identify or key requests by user
+const CurrentUserUtilTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package util
+
+import "github.com/gofiber/fiber/v2"
+
+// UserIDLocal is the c.Locals key holding the ID of the user a request is authenticated as.
+// The session middleware sets it; custom authentication middleware should set it too.
+const UserIDLocal = "user_id"
+
+// CurrentUserID returns the ID of the user the request is authenticated as
+func CurrentUserID(c *fiber.Ctx) (string, bool) {
+	id, ok := c.Locals(UserIDLocal).(string)
+	return id, ok && id != ""
+}
+`
+
+// SessionUtilTemplate generates util/session.go with the cookie session manager
+const SessionUtilTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package util
+
+import (
+	"{{.ProjectName}}/config"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
+)
+
+// sessionUserKey is the session value holding the logged in user's ID
+const sessionUserKey = "user_id"
+
+// SessionManager keeps server-side sessions identified by an HTTP-only cookie
+type SessionManager struct {
+	store      *session.Store
+	cookieName string
+}
+
+// NewSessionManager creates a session manager. Sessions are kept in storage, or in memory
+// when storage is nil.
+func NewSessionManager(cfg *config.SessionConfig, storage fiber.Storage) *SessionManager {
+	return &SessionManager{
+		store: session.New(session.Config{
+			Expiration:        cfg.Expiration,
+			Storage:           storage,
+			KeyLookup:         "cookie:" + cfg.CookieName,
+			CookieDomain:      cfg.CookieDomain,
+			CookiePath:        "/",
+			CookieSecure:      cfg.CookieSecure,
+			CookieHTTPOnly:    true,
+			CookieSameSite:    cfg.CookieSameSite,
+			CookieSessionOnly: cfg.CookieSessionOnly,
+		}),
+		cookieName: cfg.CookieName,
+	}
+}
+
+// Get returns the session of the request. Changes are stored by calling Save on it.
+// A session ID the server does not know is replaced, so clients cannot choose their own.
+func (m *SessionManager) Get(c *fiber.Ctx) (*session.Session, error) {
+	sess, err := m.store.Get(c)
+	if err != nil {
+		return nil, err
+	}
+	if sess.Fresh() && c.Cookies(m.cookieName) != "" {
+		if err := sess.Regenerate(); err != nil {
+			return nil, err
+		}
+	}
+	return sess, nil
+}
+
+// UserID returns the ID of the user logged in to the request's session, or "" when anonymous
+func (m *SessionManager) UserID(c *fiber.Ctx) (string, error) {
+	sess, err := m.Get(c)
+	if err != nil {
+		return "", err
+	}
+	userID, _ := sess.Get(sessionUserKey).(string)
+	return userID, nil
+}
+
+// Login logs userID in to the request's session. The session ID is rotated, so an ID
+// obtained before logging in cannot be used to act as the user.
+func (m *SessionManager) Login(c *fiber.Ctx, userID string) error {
+	sess, err := m.Get(c)
+	if err != nil {
+		return err
+	}
+	if err := sess.Regenerate(); err != nil {
+		return err
+	}
+
+	sess.Set(sessionUserKey, userID)
+	if err := sess.Save(); err != nil {
+		return err
+	}
+	c.Locals(UserIDLocal, userID)
+	return nil
+}
+
+// Logout deletes the request's session and expires its cookie
+func (m *SessionManager) Logout(c *fiber.Ctx) error {
+	sess, err := m.Get(c)
+	if err != nil {
+		return err
+	}
+	if err := sess.Destroy(); err != nil {
+		return err
+	}
+	c.Locals(UserIDLocal, nil)
+	return nil
+}
+`
+
+// SessionRedisUtilTemplate generates util/session_redis.go, the session storage shared by every instance through Redis
+const SessionRedisUtilTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package util
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// sessionKeyPrefix namespaces sessions in Redis
+const sessionKeyPrefix = "session:"
+
+// RedisSessionStorage stores sessions in Redis through the RedisAdapter's client. It implements
+// fiber.Storage.
+type RedisSessionStorage struct {
+	client *redis.Client
+}
+
+// NewRedisSessionStorage creates a session storage on an existing Redis client
+func NewRedisSessionStorage(client *redis.Client) *RedisSessionStorage {
+	return &RedisSessionStorage{client: client}
+}
+
+// Get returns a stored session, or nil when it does not exist
+func (s *RedisSessionStorage) Get(key string) ([]byte, error) {
+	data, err := s.client.Get(context.Background(), sessionKeyPrefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// Set stores a session for exp, or without expiry when exp is 0
+func (s *RedisSessionStorage) Set(key string, val []byte, exp time.Duration) error {
+	if key == "" || len(val) == 0 {
+		return nil
+	}
+	return s.client.Set(context.Background(), sessionKeyPrefix+key, val, exp).Err()
+}
+
+// Delete removes a session
+func (s *RedisSessionStorage) Delete(key string) error {
+	if key == "" {
+		return nil
+	}
+	return s.client.Del(context.Background(), sessionKeyPrefix+key).Err()
+}
+
+// Reset removes every session, leaving other keys in the database alone
+func (s *RedisSessionStorage) Reset() error {
+	ctx := context.Background()
+
+	var keys []string
+	iter := s.client.Scan(ctx, 0, sessionKeyPrefix+"*", 500).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	for start := 0; start < len(keys); start += 500 {
+		end := start + 500
+		if end > len(keys) {
+			end = len(keys)
+		}
+		if err := s.client.Unlink(ctx, keys[start:end]...).Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close does nothing; the client belongs to the RedisAdapter, which closes it on shutdown
+func (s *RedisSessionStorage) Close() error {
+	return nil
+}
+`
+
+// SessionMiddlewareTemplate generates middleware/session.go
+const SessionMiddlewareTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package middleware
+
+import (
+	"{{.ProjectName}}/util"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Session reads the user logged in to each request's session, so handlers and later middleware
+// get it from util.CurrentUserID. Anonymous requests do not create sessions.
+func Session(sessions *util.SessionManager) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userID, err := sessions.UserID(c)
+		if err != nil {
+			return err
+		}
+		if userID != "" {
+			c.Locals(util.UserIDLocal, userID)
+		}
+		return c.Next()
+	}
+}
+
+// RequireLogin rejects requests without a logged in user with 401, e.g. for an admin route group
+func RequireLogin() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if _, ok := util.CurrentUserID(c); !ok {
+			return fiber.NewError(fiber.StatusUnauthorized, "login required")
+		}
+		return c.Next()
+	}
+}
+`
+
+// SessionRouteTemplate generates the route switch that hands the session manager to handlers
+const SessionRouteTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package route
+
+import (
+	"{{.ProjectName}}/util"
+)
+
+// sessions logs users in and out, for handlers such as a login handler
+var sessions *util.SessionManager
+
+// UseSessions hands the session manager to the routes. Call it before SetupRoutes.
+func UseSessions(manager *util.SessionManager) {
+	sessions = manager
+}
+`
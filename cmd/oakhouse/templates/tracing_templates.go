@@ -0,0 +1,131 @@
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package templates
+
+// TracingAdapterTemplate generates the OpenTelemetry tracer provider setup
+const TracingAdapterTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"{{.ProjectName}}/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// InitializeTracing configures the global OpenTelemetry tracer provider and propagators.
+// Returns a shutdown function that flushes pending spans; it is a no-op when tracing is disabled.
+func InitializeTracing(cfg *config.Config) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+
+	settings := cfg.Tracing()
+
+	exporter, err := newSpanExporter(settings)
+	if err != nil {
+		return noop, err
+	}
+	if exporter == nil {
+		return noop, nil
+	}
+
+	res, err := resource.New(context.Background(),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(
+			attribute.String("service.name", settings.ServiceName),
+			attribute.String("deployment.environment", cfg.Env),
+		),
+	)
+	if err != nil {
+		return noop, fmt.Errorf("failed to create tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return provider.Shutdown, nil
+}
+
+// newSpanExporter builds the span exporter selected by OTEL_TRACES_EXPORTER (otlp, stdout or none)
+func newSpanExporter(settings *config.TracingConfig) (sdktrace.SpanExporter, error) {
+	switch strings.ToLower(settings.Exporter) {
+	case "", "none":
+		return nil, nil
+	case "stdout":
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout), stdouttrace.WithPrettyPrint())
+	case "otlp":
+		endpoint, err := url.Parse(settings.OTLPEndpoint)
+		if err != nil || endpoint.Host == "" {
+			return nil, fmt.Errorf("invalid OTEL_EXPORTER_OTLP_ENDPOINT %q", settings.OTLPEndpoint)
+		}
+
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(endpoint.Host)}
+		if endpoint.Scheme != "https" {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		if endpoint.Path != "" && endpoint.Path != "/" {
+			opts = append(opts, otlptracehttp.WithURLPath(endpoint.Path))
+		}
+
+		return otlptracehttp.New(context.Background(), opts...)
+	default:
+		return nil, fmt.Errorf("unsupported tracing exporter %q", settings.Exporter)
+	}
+}
+`
+
+// TracingUtilTemplate generates span helpers used by generated services
+const TracingUtilTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package util
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer delegates to whichever provider adapter.InitializeTracing installs
+var tracer = otel.Tracer("{{.ProjectName}}")
+
+// StartSpan starts a child span of the span carried by ctx
+func StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return tracer.Start(ctx, name, opts...)
+}
+
+// RecordError marks the span as failed when err is not nil
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// EndSpan records the error held by err, if any, and ends the span. Defer it with the address
+// of the method's named error result, so every error the method returns marks the span failed.
+func EndSpan(span trace.Span, err *error) {
+	if err != nil {
+		RecordError(span, *err)
+	}
+	span.End()
+}
+`
@@ -0,0 +1,86 @@
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package templates
+
+// TxUtilTemplate lets services run work across repositories in one transaction
+const TxUtilTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package util
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// txKey is the context key of the transaction opened by TxManager.Do
+type txKey struct{}
+
+// afterCommitKey is the context key of the callbacks registered with AfterCommit
+type afterCommitKey struct{}
+
+// TxManager runs units of work in a database transaction. The transaction travels in the
+// context, and repositories pick it up through DBFromContext, so any repository called with
+// that context takes part in it without being constructed differently.
+type TxManager struct {
+	db *gorm.DB
+}
+
+// NewTxManager creates a TxManager on the given connection pool
+func NewTxManager(db *gorm.DB) *TxManager {
+	return &TxManager{db: db}
+}
+
+// Do runs fn in a transaction that is committed when fn returns nil and rolled back when it
+// returns an error or panics. Calling Do inside another Do joins the outer transaction with a
+// savepoint, so the inner work can fail on its own without aborting the outer one.
+func (m *TxManager) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	db := DBFromContext(ctx, m.db)
+	outer, nested := ctx.Value(afterCommitKey{}).(*[]func())
+
+	var callbacks []func()
+	err := db.Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(WithTx(ctx, tx), afterCommitKey{}, &callbacks))
+	})
+	if err != nil {
+		return err
+	}
+
+	// A savepoint hands its callbacks to the enclosing transaction
+	if nested {
+		*outer = append(*outer, callbacks...)
+		return nil
+	}
+	for _, callback := range callbacks {
+		callback()
+	}
+	return nil
+}
+
+// AfterCommit runs fn once the transaction opened by TxManager.Do in ctx commits, or right away
+// when ctx carries none. Callbacks of a transaction that rolls back are dropped.
+func AfterCommit(ctx context.Context, fn func()) {
+	if callbacks, ok := ctx.Value(afterCommitKey{}).(*[]func()); ok {
+		*callbacks = append(*callbacks, fn)
+		return
+	}
+	fn()
+}
+
+// WithTx returns a copy of ctx carrying tx
+func WithTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// TxFromContext returns the transaction carried by ctx, if any
+func TxFromContext(ctx context.Context) (*gorm.DB, bool) {
+	tx, ok := ctx.Value(txKey{}).(*gorm.DB)
+	return tx, ok
+}
+
+// DBFromContext returns the transaction carried by ctx, or db when there is none, bound to ctx
+func DBFromContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+`
@@ -0,0 +1,148 @@
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package templates
+
+// ETagUtilTemplate holds the optimistic locking helpers shared by versioned resources
+const ETagUtilTemplate = `// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package util
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrVersionConflict is returned when a row changed since the version the caller based its write on
+var ErrVersionConflict = errors.New("version conflict")
+
+// ErrInvalidETag is returned for an If-Match header that is not an ETag of a versioned resource
+var ErrInvalidETag = errors.New("invalid ETag")
+
+// FormatETag renders a row version as a strong ETag such as "3"
+func FormatETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// IfMatch is the precondition of an If-Match header: a write may proceed only while the row is
+// at one of the versions it lists. A nil *IfMatch is no precondition at all.
+type IfMatch struct {
+	versions []int64
+}
+
+// MatchVersion is the precondition that the row is still at the given version
+func MatchVersion(version int64) *IfMatch {
+	return &IfMatch{versions: []int64{version}}
+}
+
+// ParseIfMatch reads an If-Match header, "*" or a comma separated list of ETags. It returns nil
+// when the header is missing or "*", in which case the write is not conditional. If-Match uses
+// the strong comparison (RFC 9110, section 13.1.1), so weak ETags are accepted but never match.
+func ParseIfMatch(header string) (*IfMatch, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+	tags, err := parseETagList(header)
+	if err != nil {
+		return nil, err
+	}
+
+	match := &IfMatch{}
+	for _, tag := range tags {
+		if tag.weak {
+			continue
+		}
+		if version, err := strconv.ParseInt(tag.opaque, 10, 64); err == nil {
+			match.versions = append(match.versions, version)
+		}
+	}
+	return match, nil
+}
+
+// Matches reports whether a row at version satisfies the precondition
+func (m *IfMatch) Matches(version int64) bool {
+	if m == nil {
+		return true
+	}
+	for _, listed := range m.versions {
+		if listed == version {
+			return true
+		}
+	}
+	return false
+}
+
+// ETagMatches reports whether an If-None-Match header lists the given version, so the
+// client's cached copy is current and a 304 Not Modified can be sent. If-None-Match uses the
+// weak comparison, so W/"3" matches version 3 as well.
+func ETagMatches(header string, version int64) bool {
+	header = strings.TrimSpace(header)
+	if header == "*" {
+		return true
+	}
+	tags, err := parseETagList(header)
+	if err != nil {
+		return false
+	}
+	for _, tag := range tags {
+		if tag.opaque == strconv.FormatInt(version, 10) {
+			return true
+		}
+	}
+	return false
+}
+
+// entityTag is one ETag of a header list, without its quotes
+type entityTag struct {
+	opaque string
+	weak   bool
+}
+
+// parseETagList splits a comma separated list of ETags. Commas may appear inside the quotes of
+// an ETag, so the list is scanned tag by tag rather than split on commas.
+func parseETagList(header string) ([]entityTag, error) {
+	var tags []entityTag
+	rest := header
+	for {
+		rest = strings.TrimLeft(rest, " \t,")
+		if rest == "" {
+			break
+		}
+
+		var tag entityTag
+		if strings.HasPrefix(rest, "W/") {
+			tag.weak = true
+			rest = rest[len("W/"):]
+		}
+		if !strings.HasPrefix(rest, "\"") {
+			return nil, ErrInvalidETag
+		}
+		end := strings.IndexByte(rest[1:], '"')
+		if end == -1 {
+			return nil, ErrInvalidETag
+		}
+		tag.opaque = rest[1 : end+1]
+		tags = append(tags, tag)
+
+		rest = strings.TrimLeft(rest[end+2:], " \t")
+		if rest != "" && rest[0] != ',' {
+			return nil, ErrInvalidETag
+		}
+	}
+	if len(tags) == 0 {
+		return nil, ErrInvalidETag
+	}
+	return tags, nil
+}
+`
+
+// VersionMigrationUpTemplate adds the optimistic locking column to a versioned resource's table
+const VersionMigrationUpTemplate = `-- 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+-- Optimistic locking for {{.TableName}}: every update increments version and only
+-- succeeds while the row still has the version it was read with.
+ALTER TABLE {{.TableName}} ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1;
+`
+
+// VersionMigrationDownTemplate removes the version column
+const VersionMigrationDownTemplate = `-- 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+ALTER TABLE {{.TableName}} DROP COLUMN IF EXISTS version;
+`
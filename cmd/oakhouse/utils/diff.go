@@ -0,0 +1,125 @@
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 3
+
+// LineDiff returns a unified diff between two versions of a file, or an empty string when they are equal.
+// Lines are matched with a longest common subsequence, which is fast enough for generated source files.
+func LineDiff(path, before, after string) string {
+	if before == after {
+		return ""
+	}
+
+	a := splitLines(before)
+	b := splitLines(after)
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	type diffLine struct {
+		op   byte
+		text string
+		aPos int
+		bPos int
+	}
+
+	var lines []diffLine
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i], i, j})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, diffLine{'-', a[i], i, j})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', b[j], i, j})
+			j++
+		}
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- a/%s\n+++ b/%s\n", path, path)
+
+	for start := 0; start < len(lines); {
+		// Find the next change and the hunk around it
+		first := start
+		for first < len(lines) && lines[first].op == ' ' {
+			first++
+		}
+		if first == len(lines) {
+			break
+		}
+
+		hunkStart := first - diffContext
+		if hunkStart < start {
+			hunkStart = start
+		}
+		hunkEnd := first
+		for k := first; k < len(lines) && k-hunkEnd <= 2*diffContext; k++ {
+			if lines[k].op != ' ' {
+				hunkEnd = k
+			}
+		}
+		hunkEnd += diffContext
+		if hunkEnd >= len(lines) {
+			hunkEnd = len(lines) - 1
+		}
+
+		aCount, bCount := 0, 0
+		for _, line := range lines[hunkStart : hunkEnd+1] {
+			if line.op != '+' {
+				aCount++
+			}
+			if line.op != '-' {
+				bCount++
+			}
+		}
+		// Empty ranges start at the line before them, as in diff -u
+		aStart, bStart := lines[hunkStart].aPos+1, lines[hunkStart].bPos+1
+		if aCount == 0 {
+			aStart--
+		}
+		if bCount == 0 {
+			bStart--
+		}
+		fmt.Fprintf(&out, "@@ -%d,%d +%d,%d @@\n", aStart, aCount, bStart, bCount)
+		for _, line := range lines[hunkStart : hunkEnd+1] {
+			fmt.Fprintf(&out, "%c%s\n", line.op, line.text)
+		}
+
+		start = hunkEnd + 1
+	}
+
+	return out.String()
+}
+
+// splitLines splits file content into lines without the trailing newline
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+}
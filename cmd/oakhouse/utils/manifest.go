@@ -0,0 +1,39 @@
+// 🚀 Proudly Created by Htet Waiyan From Oakhouse 🏡
+package utils
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// ManifestFile is the project file recording which CLI version generated or last upgraded the project
+const ManifestFile = ".oakhouse.json"
+
+// ProjectManifest is the content of ManifestFile
+type ProjectManifest struct {
+	Version string   `json:"version"`
+	Notes   []string `json:"notes,omitempty"` // Manual follow-ups left by the last upgrade
+}
+
+// ReadProjectManifest reads ManifestFile from the current directory
+func ReadProjectManifest() (*ProjectManifest, error) {
+	data, err := os.ReadFile(ManifestFile)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &ProjectManifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// WriteProjectManifest writes ManifestFile to the current directory
+func WriteProjectManifest(manifest *ProjectManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(ManifestFile, append(data, '\n'), 0644)
+}
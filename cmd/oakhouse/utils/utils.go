@@ -11,7 +11,7 @@ import (
 )
 
 // Version represents the current version of Go To Oakhouse
-const Version = "1.34.0"
+const Version = "1.35.0"
 
 // Field represents a struct field with metadata for code generation
 type Field struct {
@@ -22,6 +22,32 @@ type Field struct {
 	JsonTag   string
 	QueryType string
 	QueryTag  string
+	Column    string
+	Nullable  bool
+	NoPointer bool
+}
+
+// Pointer reports whether the field is rendered as a pointer, which holds for nullable fields
+// unless their type already has a nil value (JSON, binary and array columns)
+func (f Field) Pointer() bool {
+	return f.Nullable && !f.NoPointer
+}
+
+// FilterKind classifies the field for the list filter grammar as "String", "Number", "Time",
+// "Bool" or "UUID", deciding which operators and value parsing its filters accept
+func (f Field) FilterKind() string {
+	switch {
+	case f.Type == "time.Time":
+		return "Time"
+	case f.Type == "bool":
+		return "Bool"
+	case f.Type == "uuid.UUID":
+		return "UUID"
+	case strings.HasPrefix(f.Type, "int"), strings.HasPrefix(f.Type, "uint"), strings.HasPrefix(f.Type, "float"):
+		return "Number"
+	default:
+		return "String"
+	}
 }
 
 // WriteFile creates files from Go templates with dynamic data injection.
@@ -55,6 +81,21 @@ func WriteFile(filename, tmpl string, data interface{}) error {
 	return nil
 }
 
+// RenderTemplate executes a Go template and returns the result instead of writing it to disk.
+// Used where generated content must be compared or previewed before it is written.
+func RenderTemplate(tmpl string, data interface{}) (string, error) {
+	t, err := template.New("template").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var out strings.Builder
+	if err := t.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("failed to execute template: %w", err)
+	}
+	return out.String(), nil
+}
+
 // ParseFields parses field definitions from command line arguments into structured Field objects.
 // Converts string field definitions (name:type format) into Field structs with proper Go types,
 // GORM tags, and JSON tags for database and API serialization.
@@ -75,6 +116,7 @@ func ParseFields(fields []string) []Field {
 				JsonTag:   lowerName,
 				QueryType: "*" + fieldType,
 				QueryTag:  lowerName,
+				Column:    lowerName,
 			})
 		}
 	}
@@ -90,16 +132,77 @@ func MapGoType(t string) string {
 		return "string"
 	case "int":
 		return "int"
-	case "int64":
-		return "int64"
-	case "float64":
+	case "int16", "int32", "int64", "uint", "uint32", "uint64", "float32", "float64":
+		return strings.ToLower(t)
+	case "float":
 		return "float64"
 	case "bool":
 		return "bool"
-	case "time":
+	case "time", "time.time":
 		return "time.Time"
+	case "uuid", "uuid.uuid":
+		return "uuid.UUID"
+	default:
+		return "string"
+	}
+}
+
+// MapSQLType maps a PostgreSQL column type to the Go type used in generated models.
+// Takes the information_schema data_type and udt_name of the column; arrays are mapped to
+// the lib/pq array types, and types without a closer Go equivalent (money, enums, network
+// and range types) are mapped to string.
+func MapSQLType(dataType, udtName string) string {
+	if strings.EqualFold(dataType, "ARRAY") {
+		switch strings.TrimPrefix(strings.ToLower(udtName), "_") {
+		case "int2", "int4", "int8":
+			return "pq.Int64Array"
+		case "float4", "float8", "numeric":
+			return "pq.Float64Array"
+		case "bool":
+			return "pq.BoolArray"
+		default:
+			return "pq.StringArray"
+		}
+	}
+
+	switch strings.ToLower(udtName) {
+	case "int2":
+		return "int16"
+	case "int4":
+		return "int32"
+	case "int8":
+		return "int64"
+	case "float4":
+		return "float32"
+	case "float8", "numeric":
+		return "float64"
+	case "bool":
+		return "bool"
 	case "uuid":
 		return "uuid.UUID"
+	case "date", "timestamp", "timestamptz":
+		return "time.Time"
+	case "json", "jsonb":
+		return "json.RawMessage"
+	case "bytea":
+		return "[]byte"
+	}
+
+	switch strings.ToLower(dataType) {
+	case "smallint":
+		return "int16"
+	case "integer":
+		return "int32"
+	case "bigint":
+		return "int64"
+	case "real":
+		return "float32"
+	case "double precision", "numeric":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "date", "timestamp without time zone", "timestamp with time zone":
+		return "time.Time"
 	default:
 		return "string"
 	}
@@ -123,6 +226,50 @@ func GetModuleName() (string, error) {
 	return "", fmt.Errorf("module name not found in go.mod")
 }
 
+// FileExists reports whether a file or directory exists at the given path
+func FileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// CompareVersions compares dotted version strings such as "1.34.0", "v1.21" or "go1.22.3".
+// Returns -1, 0 or 1; missing components count as zero and pre-release suffixes are ignored.
+func CompareVersions(a, b string) int {
+	normalize := func(v string) []int {
+		v = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(v), "go"), "v")
+		var parts []int
+		for _, part := range strings.Split(v, ".") {
+			n := 0
+			for _, r := range part {
+				if r < '0' || r > '9' {
+					break
+				}
+				n = n*10 + int(r-'0')
+			}
+			parts = append(parts, n)
+		}
+		return parts
+	}
+
+	pa, pb := normalize(a), normalize(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
 // GetCurrentTimestamp returns the current timestamp in a formatted string
 func GetCurrentTimestamp() string {
 	return time.Now().Format("2006-01-02 15:04:05")
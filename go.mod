@@ -2,7 +2,10 @@ module github.com/Oakhouse-IoT-Solutions/go-to-oakhouse
 
 go 1.21
 
-require github.com/spf13/cobra v1.8.0
+require (
+	github.com/lib/pq v1.10.9
+	github.com/spf13/cobra v1.8.0
+)
 
 require (
 	github.com/Oakhouse-IoT-Solutions/go-to-oakhouse v1.32.0